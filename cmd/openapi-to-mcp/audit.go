@@ -0,0 +1,58 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/audit"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+	"gopkg.in/yaml.v3"
+)
+
+// runAudit implements the `audit` subcommand, which reviews a generated MCP configuration for risky exposures
+func runAudit(args []string) {
+	flags := flag.NewFlagSet("audit", flag.ExitOnError)
+	inputFile := flags.String("input", "", "Path to the MCP configuration file (YAML or JSON)")
+	format := flags.String("format", "text", "Report format (text or json)")
+	minScore := flags.Int("min-score", 0, "Exit with an error if the score is below this value")
+	flags.Parse(args)
+
+	if *inputFile == "" {
+		fmt.Println("Error: input file is required")
+		flags.Usage()
+		os.Exit(1)
+	}
+
+	data, err := os.ReadFile(*inputFile)
+	if err != nil {
+		fmt.Printf("Error reading MCP configuration: %v\n", err)
+		os.Exit(1)
+	}
+	var config models.MCPConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		fmt.Printf("Error parsing MCP configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	report := audit.Audit(&config)
+	if *format == "json" {
+		encoded, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			fmt.Printf("Error encoding report: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(encoded))
+	} else {
+		for _, finding := range report.Findings {
+			fmt.Println(finding)
+		}
+		fmt.Println(report.Summary())
+	}
+
+	if report.Score < *minScore {
+		fmt.Fprintf(os.Stderr, "Error: audit score %d is below the minimum of %d\n", report.Score, *minScore)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/converter"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/manifest"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/output"
+)
+
+// toolSetFile is the name of the tool set written by batch conversions, before its extension
+const toolSetFile = "toolset"
+
+// specFiles lists the JSON and YAML files of a directory, sorted by name
+func specFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading input directory %s: %w", dir, err)
+	}
+	var files []string
+	for _, entry := range entries {
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".json", ".yaml", ".yml":
+			if !entry.IsDir() {
+				files = append(files, filepath.Join(dir, entry.Name()))
+			}
+		}
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("input directory %s has no JSON or YAML files", dir)
+	}
+	return files, nil
+}
+
+// writeBatch writes each configuration to a file of dir named after its server, with its smoke tests
+// if emitTests is set, and the tool set listing their tools. It returns the tool set file.
+func writeBatch(dir string, configs []*models.MCPConfig, toolSet *models.ToolSetConfig, format string, emitTests bool) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+	ext := ".yaml"
+	if format == output.FormatJSON {
+		ext = ".json"
+	}
+	names, err := batchFileNames(configs, emitTests)
+	if err != nil {
+		return "", err
+	}
+	for _, config := range configs {
+		name := names[config.Server.Name]
+		if err := writeConfig(filepath.Join(dir, name+ext), config, format); err != nil {
+			return "", err
+		}
+		if emitTests {
+			if err := writeDocument(filepath.Join(dir, name+"-tests"+ext), converter.BuildSmokeTests(config), format); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	file := filepath.Join(dir, toolSetFile+ext)
+	if err := writeDocument(file, map[string]any{"toolSet": toolSet}, format); err != nil {
+		return "", err
+	}
+	return file, nil
+}
+
+// batchFileNames derives the file name of each server, before its extension, from its name. Server
+// names can come from the specs, so names that are paths are rejected, and the others are slugged
+// so that no file is written outside the output directory or over another file.
+func batchFileNames(configs []*models.MCPConfig, emitTests bool) (map[string]string, error) {
+	names := make(map[string]string, len(configs))
+	owners := map[string]string{toolSetFile: "the tool set"}
+	for _, config := range configs {
+		server := config.Server.Name
+		if strings.ContainsAny(server, `/\`) || strings.Contains(server, "..") {
+			return nil, fmt.Errorf("server name %q cannot be used as a file name", server)
+		}
+		name := manifest.ResourceName(server)
+		files := []string{name}
+		if emitTests {
+			files = append(files, name+"-tests")
+		}
+		for _, file := range files {
+			if owner, ok := owners[file]; ok {
+				return nil, fmt.Errorf("file %s of server %q conflicts with %s", file, server, owner)
+			}
+			owners[file] = fmt.Sprintf("server %q", server)
+		}
+		names[server] = name
+	}
+	return names, nil
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/parser"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/scaffold"
+)
+
+// pathFlags are flags holding file paths, which are resolved relative to the config file
+var pathFlags = map[string]bool{"input": true, "input-dir": true, "output": true, "output-dir": true, "template": true, "filter-file": true, "transform-script": true}
+
+// commandFlags are flags running programs, which a config file only sets when it is given with
+// --config, so that converting in a checked-out repository never runs its scripts
+var commandFlags = map[string]bool{"transform-script": true}
+
+// findConfigFile returns the project config file of the current directory, or "" if there is none
+func findConfigFile() string {
+	if _, err := os.Stat(scaffold.ProjectFile); err != nil {
+		return ""
+	}
+	return scaffold.ProjectFile
+}
+
+// applyConfigFile sets the flags that were not given on the command line from a YAML file
+// whose keys are flag names. Lists set repeatable flags such as input once per item and are
+// joined with commas for other flags; maps set repeatable key=value flags such as credential-from.
+// A discovered file, read without --config, cannot set commandFlags.
+func applyConfigFile(flags *flag.FlagSet, path string, discovered bool) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	var values map[string]any
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	explicit := make(map[string]bool)
+	flags.Visit(func(f *flag.Flag) {
+		explicit[f.Name] = true
+	})
+
+	for name, value := range values {
+		if flags.Lookup(name) == nil || name == "config" {
+			return fmt.Errorf("unknown option %q in config file %s", name, path)
+		}
+		if discovered && commandFlags[name] {
+			return fmt.Errorf("option %q in config file %s is only read with --config %s", name, path, path)
+		}
+		if explicit[name] || value == nil {
+			continue
+		}
+		for _, text := range configValues(value, flags.Lookup(name)) {
+			if pathFlags[name] && !filepath.IsAbs(text) && !parser.IsURL(text) {
+				text = filepath.Join(filepath.Dir(path), text)
+			}
+			if err := flags.Set(name, text); err != nil {
+				return fmt.Errorf("invalid value for %q in config file %s: %w", name, path, err)
+			}
+		}
+	}
+	return nil
+}
+
+// configValues converts the value of a flag in a config file to the values to set
+func configValues(value any, f *flag.Flag) []string {
+	_, repeatable := f.Value.(*stringList)
+	var values []string
+	switch value := value.(type) {
+	case []any:
+		for _, item := range value {
+			values = append(values, fmt.Sprint(item))
+		}
+		if !repeatable {
+			values = []string{strings.Join(values, ",")}
+		}
+	case map[string]any:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			values = append(values, key+"="+fmt.Sprint(value[key]))
+		}
+	default:
+		values = []string{fmt.Sprint(value)}
+	}
+	return values
+}
+
+// readFilterFile reads a YAML filter file
+func readFilterFile(path string) (models.Filter, error) {
+	var filter models.Filter
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return filter, fmt.Errorf("failed to read filter file: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &filter); err != nil {
+		return filter, fmt.Errorf("failed to parse filter file %s: %w", path, err)
+	}
+	return filter, nil
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/converter"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+)
+
+// printSummary prints the statistics of a conversion for `--dry-run`
+func printSummary(w io.Writer, inputFile string, config *models.MCPConfig, c *converter.Converter) {
+	fmt.Fprintf(w, "%s\n", inputFile)
+
+	fmt.Fprintf(w, "  Tools: %d\n", len(config.Tools))
+	if len(config.Tools) > 0 {
+		table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		totalArgs := 0
+		for _, tool := range config.Tools {
+			required := 0
+			for _, arg := range tool.Args {
+				if arg.Required {
+					required++
+				}
+			}
+			totalArgs += len(tool.Args)
+			fmt.Fprintf(table, "    %s\t%s %s\t%s (%d required)\n", tool.Name, tool.RequestTemplate.Method, tool.RequestTemplate.URL, plural(len(tool.Args), "arg"), required)
+		}
+		table.Flush()
+		fmt.Fprintf(w, "  Average args per tool: %.1f\n", float64(totalArgs)/float64(len(config.Tools)))
+	}
+
+	if len(config.Resources) > 0 {
+		fmt.Fprintf(w, "  Resources: %d\n", len(config.Resources))
+	}
+	if len(config.Prompts) > 0 {
+		fmt.Fprintf(w, "  Prompts: %d\n", len(config.Prompts))
+	}
+	if len(config.Events) > 0 {
+		fmt.Fprintf(w, "  Events: %d\n", len(config.Events))
+	}
+
+	fmt.Fprintf(w, "  Skipped operations: %d\n", len(c.Skipped()))
+	for _, skipped := range c.Skipped() {
+		fmt.Fprintf(w, "    %s\n", skipped)
+	}
+
+	fmt.Fprintf(w, "  Security schemes: %d\n", len(config.Server.SecuritySchemes))
+	for _, scheme := range config.Server.SecuritySchemes {
+		fmt.Fprintf(w, "    %s: %s\n", scheme.ID, describeScheme(scheme))
+	}
+
+	fmt.Fprintf(w, "  Warnings: %d\n", len(c.Warnings()))
+	for _, warning := range c.Warnings() {
+		fmt.Fprintf(w, "    %s\n", warning)
+	}
+}
+
+// describeScheme describes how a security scheme passes credentials
+func describeScheme(scheme models.SecurityScheme) string {
+	parts := []string{scheme.Type}
+	if scheme.Scheme != "" {
+		parts = append(parts, scheme.Scheme)
+	}
+	if scheme.In != "" {
+		parts = append(parts, "in "+scheme.In)
+	}
+	if scheme.Name != "" {
+		parts = append(parts, scheme.Name)
+	}
+	return strings.Join(parts, " ")
+}
+
+// plural formats a count with a singular or plural noun
+func plural(count int, noun string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, noun)
+	}
+	return fmt.Sprintf("%d %ss", count, noun)
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/exporter"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/output"
+	"gopkg.in/yaml.v3"
+)
+
+// runMCPToOpenAPI implements the `mcp-to-openapi` subcommand, which reconstructs an OpenAPI document from an MCP configuration
+func runMCPToOpenAPI(args []string) {
+	flags := flag.NewFlagSet("mcp-to-openapi", flag.ExitOnError)
+	inputFile := flags.String("input", "", "Path to the MCP configuration file (YAML or JSON)")
+	outputFile := flags.String("output", "", "Path to the OpenAPI document to write (default: standard output)")
+	format := flags.String("format", "", "Output format (yaml or json, default: from the output file extension, or yaml)")
+	flags.Parse(args)
+
+	if *inputFile == "" {
+		fmt.Println("Error: input file is required")
+		flags.Usage()
+		os.Exit(1)
+	}
+
+	data, err := os.ReadFile(*inputFile)
+	if err != nil {
+		fmt.Printf("Error reading MCP configuration: %v\n", err)
+		os.Exit(1)
+	}
+	var config models.MCPConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		fmt.Printf("Error parsing MCP configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	doc, err := exporter.Export(&config)
+	if err != nil {
+		fmt.Printf("Error exporting OpenAPI document: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *format == "" {
+		*format = output.FormatYAML
+		if strings.EqualFold(filepath.Ext(*outputFile), ".json") {
+			*format = output.FormatJSON
+		}
+	}
+	encoded, err := encodeDocument(doc, *format)
+	if err != nil {
+		fmt.Printf("Error encoding OpenAPI document: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *outputFile == "" {
+		os.Stdout.Write(encoded)
+		return
+	}
+	if err := os.WriteFile(*outputFile, encoded, 0644); err != nil {
+		fmt.Printf("Error writing OpenAPI document: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Successfully exported MCP configuration to OpenAPI document: %s\n", *outputFile)
+}
+
+// encodeDocument encodes an OpenAPI document as YAML or JSON
+func encodeDocument(doc any, format string) ([]byte, error) {
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	if format == output.FormatJSON {
+		return append(data, '\n'), nil
+	}
+
+	// JSON is valid YAML, so decoding it as a node keeps the key order when re-encoding
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, fmt.Errorf("failed to encode YAML: %w", err)
+	}
+	resetStyle(&node)
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return nil, fmt.Errorf("failed to encode YAML: %w", err)
+	}
+	return buffer.Bytes(), nil
+}
+
+// resetStyle switches a node decoded from JSON to the block style
+func resetStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetStyle(child)
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/generator"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+)
+
+// runGenerate implements the `generate` subcommand, which generates programs from OpenAPI specifications
+func runGenerate(args []string) {
+	if len(args) == 0 || args[0] != "server" {
+		fmt.Println("Usage: openapi-to-mcp generate server --input <spec> --output <dir>")
+		os.Exit(1)
+	}
+
+	flags := flag.NewFlagSet("generate server", flag.ExitOnError)
+	inputFile := flags.String("input", "", "Path to the OpenAPI specification file (JSON or YAML)")
+	outputDir := flags.String("output", "", "Directory to write the server sources to")
+	module := flags.String("module", "", "Go module path of the generated server (default: name of the output directory)")
+	baseURL := flags.String("base-url", "", "URL of the API, overriding the servers of the specification")
+	toolNamePrefix := flags.String("tool-prefix", "", "Prefix for tool names")
+	templateFile := flags.String("template", "", "Path to a template file to patch the MCP configuration")
+	profile := flags.String("profile", "", "Profile providing default conversion options")
+	validate := flags.Bool("validate", false, "Validate the OpenAPI specification")
+	flags.Parse(args[1:])
+
+	if *inputFile == "" || *outputDir == "" {
+		fmt.Println("Error: input file and output directory are required")
+		flags.Usage()
+		os.Exit(1)
+	}
+
+	config, err := convertFile(*inputFile, *validate, models.ConvertOptions{
+		BaseURL:        *baseURL,
+		ToolNamePrefix: *toolNamePrefix,
+		TemplatePath:   *templateFile,
+		Profile:        *profile,
+	})
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *module == "" {
+		absDir, err := filepath.Abs(*outputDir)
+		if err != nil {
+			fmt.Printf("Error resolving output directory: %v\n", err)
+			os.Exit(1)
+		}
+		*module = generator.ModuleName(filepath.Base(absDir))
+	}
+
+	files, err := generator.Server(config, *module)
+	if err != nil {
+		fmt.Printf("Error generating server: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := os.MkdirAll(*outputDir, 0755); err != nil {
+		fmt.Printf("Error creating output directory: %v\n", err)
+		os.Exit(1)
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(*outputDir, name), data, 0644); err != nil {
+			fmt.Printf("Error writing %s: %v\n", name, err)
+			os.Exit(1)
+		}
+	}
+
+	fmt.Printf("Successfully generated MCP server: %s\n", *outputDir)
+	fmt.Printf("Run it with: cd %s && go run . --transport stdio\n", *outputDir)
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+	"gopkg.in/yaml.v3"
+)
+
+// lockFileSuffix names the lock file written next to the output of incremental conversions
+const lockFileSuffix = "lock"
+
+// baseline is the output and lock file of a previous incremental conversion
+type baseline struct {
+	config *models.MCPConfig
+	lock   *models.LockFile
+}
+
+// readBaseline reads the configuration written to path by a previous conversion, and its lock
+// file. Missing files are left nil, so the first incremental conversion generates every tool.
+func readBaseline(path string) (*baseline, error) {
+	var previous baseline
+	if err := readOptionalYAML(path, &previous.config); err != nil {
+		return nil, fmt.Errorf("reading previous MCP configuration: %w", err)
+	}
+	if err := readOptionalYAML(suffixedFileName(path, lockFileSuffix), &previous.lock); err != nil {
+		return nil, fmt.Errorf("reading lock file: %w", err)
+	}
+	return &previous, nil
+}
+
+// readOptionalYAML decodes a YAML or JSON file into a new value of *target, leaving it nil if
+// the file does not exist
+func readOptionalYAML[T any](path string, target **T) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	value := new(T)
+	if err := yaml.Unmarshal(data, value); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	*target = value
+	return nil
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/scaffold"
+)
+
+// runInit implements the `init` subcommand, which scaffolds a template, a filter file and a project config for a spec
+func runInit(args []string) {
+	flags := flag.NewFlagSet("init", flag.ExitOnError)
+	inputFile := flags.String("input", "", "Path to the OpenAPI specification file (JSON or YAML), or a Postman collection")
+	dir := flags.String("dir", ".", "Directory to write the starter files to")
+	outputFile := flags.String("output", "", "Path of the MCP configuration to generate, written into the project config (default: <input name>-mcp.yaml)")
+	force := flags.Bool("force", false, "Overwrite existing files")
+	flags.BoolVar(&externalRefs, "allow-external-refs", false, "Resolve $ref references to other files and URLs")
+	flags.Parse(args)
+
+	if *inputFile == "" {
+		fmt.Println("Error: input file is required")
+		flags.Usage()
+		os.Exit(1)
+	}
+
+	p, _, err := parseSpec(*inputFile, false, false)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// The project config refers to the spec relative to the directory it is written to
+	input, err := relativePath(*dir, *inputFile)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	output := *outputFile
+	if output != "" {
+		if output, err = relativePath(*dir, output); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	files, err := scaffold.Files(p.GetDocument(), scaffold.Options{Input: input, Output: output})
+	if err != nil {
+		fmt.Printf("Error creating starter files: %v\n", err)
+		os.Exit(1)
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		path := filepath.Join(*dir, name)
+		if _, err := os.Stat(path); err == nil && !*force {
+			fmt.Printf("Error: %s already exists, use --force to overwrite it\n", path)
+			os.Exit(1)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if err := os.MkdirAll(*dir, 0755); err != nil {
+		fmt.Printf("Error creating directory: %v\n", err)
+		os.Exit(1)
+	}
+	for _, name := range names {
+		path := filepath.Join(*dir, name)
+		if err := os.WriteFile(path, files[name], 0644); err != nil {
+			fmt.Printf("Error writing %s: %v\n", path, err)
+			os.Exit(1)
+		}
+		fmt.Printf("Created %s\n", path)
+	}
+	fmt.Printf("Edit the files, then run: openapi-to-mcp --config %s\n", filepath.Join(*dir, scaffold.ProjectFile))
+}
+
+// relativePath expresses a path relative to a directory
+func relativePath(dir, path string) (string, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Rel(absDir, absPath)
+}
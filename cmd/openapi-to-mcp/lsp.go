@@ -0,0 +1,25 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/lsp"
+)
+
+// runLSP implements the `lsp` subcommand, which serves MCP configuration validation over stdio
+func runLSP(args []string) {
+	flags := flag.NewFlagSet("lsp", flag.ExitOnError)
+	flags.Parse(args)
+
+	server, err := lsp.NewServer(os.Stdin, os.Stdout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error starting language server: %v\n", err)
+		os.Exit(1)
+	}
+	if err := server.Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error serving language server: %v\n", err)
+		os.Exit(1)
+	}
+}
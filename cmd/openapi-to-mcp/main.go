@@ -5,103 +5,638 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/higress-group/openapi-to-mcpserver/pkg/converter"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/manifest"
 	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/output"
 	"github.com/higress-group/openapi-to-mcpserver/pkg/parser"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/postman"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/scaffold"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/version"
 	"gopkg.in/yaml.v3"
 )
 
 func main() {
+	// Dispatch subcommands
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "schema":
+			runSchema(os.Args[2:])
+			return
+		case "lsp":
+			runLSP(os.Args[2:])
+			return
+		case "generate":
+			runGenerate(os.Args[2:])
+			return
+		case "init":
+			runInit(os.Args[2:])
+			return
+		case "audit":
+			runAudit(os.Args[2:])
+			return
+		case "mcp-to-openapi":
+			runMCPToOpenAPI(os.Args[2:])
+			return
+		}
+	}
+
 	// Define command-line flags
-	inputFile := flag.String("input", "", "Path to the OpenAPI specification file (JSON or YAML)")
+	var inputFiles stringList
+	flag.Var(&inputFiles, "input", "Path to the OpenAPI specification file (JSON or YAML); repeat to merge several specs")
+	var inputDirs stringList
+	flag.Var(&inputDirs, "input-dir", "Directory of OpenAPI specifications (JSON or YAML) to convert; repeat for several directories")
 	outputFile := flag.String("output", "", "Path to the output MCP configuration file (YAML)")
-	serverName := flag.String("server-name", "openapi-server", "Name of the MCP server")
+	batchDir := flag.String("output-dir", "", "Directory receiving one configuration per specification, named after its server, and a tool set listing the tools of all servers")
+	toolSetName := flag.String("toolset-name", "", "Name of the tool set written with --output-dir (default: the name of the output directory)")
+	serverName := flag.String("server-name", "", "Name of the MCP server (default: derived from the specification, see --name-from)")
+	nameFrom := flag.String("name-from", "", "How to derive the server name when --server-name is not set: title (default), title-version or a Go template such as \"{{.Title}}-{{.Version}}\"")
+	baseURL := flag.String("base-url", "", "URL of the API, overriding the servers of the specification")
+	var credentialRefs stringList
+	flag.Var(&credentialRefs, "credential-from", "Reference the credential of a security scheme instead of embedding it, as ID=env:NAME, ID=file:PATH or ID=secret:REF; repeat for several schemes")
+	passthroughSchemes := flag.String("passthrough-schemes", "", "Comma-separated security scheme IDs whose tools pass the credentials of the MCP client through to the API")
+	passthroughTags := flag.String("passthrough-tags", "", "Comma-separated tags whose tools pass the credentials of the MCP client through to the API")
 	toolNamePrefix := flag.String("tool-prefix", "", "Prefix for tool names")
+	prefixByTag := flag.Bool("prefix-by-tag", false, "Namespace tool names with the first tag of their operation, e.g. users.list")
 	format := flag.String("format", "yaml", "Output format (yaml or json)")
 	validate := flag.Bool("validate", false, "Validate the OpenAPI specification")
 	templateFile := flag.String("template", "", "Path to a template file to patch the output")
+	transformScript := flag.String("transform-script", "", "Path to an executable rewriting each tool, which receives the tool as JSON on stdin and prints the rewritten tool as JSON")
+	descriptionFormat := flag.String("description-format", "", "How to render HTML in descriptions (raw, markdown or text; default \"raw\")")
+	descriptionTemplate := flag.String("description-template", "", "Go template for tool descriptions using .Method, .Path, .OperationID, .Summary, .Description and .Tags, e.g. \"[{{.Method}} {{.Path}}] {{.Summary}}\"")
+	maxDescriptionLength := flag.Int("max-description-length", 0, "Maximum length of tool descriptions, truncated at sentence boundaries (0 means unlimited)")
+	maxArgDescriptionLength := flag.Int("max-arg-description-length", 0, "Maximum length of argument descriptions, truncated at sentence boundaries (0 means unlimited)")
+	descriptionSummary := flag.Bool("description-summary", false, "Use the operation summary or the first sentence instead of full descriptions")
+	language := flag.String("lang", "", "Preferred language for descriptions taken from x-description-i18n extensions (e.g. zh-CN)")
+	deriveAnnotations := flag.Bool("derive-annotations", false, "Derive MCP tool annotations (readOnlyHint, destructiveHint, idempotentHint) from HTTP methods")
+	responseMaxFields := flag.Int("response-max-fields", 0, "Prepend a description of the response structure to responses, listing at most this many fields per level (0 disables it)")
+	responseMaxDepth := flag.Int("response-max-depth", 0, "Number of nesting levels of the response description (default: 10)")
+	rateLimitInDescription := flag.Bool("rate-limit-in-description", false, "Append the rate limit documented by x-ratelimit-* extensions to tool descriptions")
+	getAsResources := flag.Bool("get-as-resources", false, "Expose parameterless GET operations as MCP resources instead of tools")
+	foldConstants := flag.Bool("fold-constants", false, "Set required path, query and header parameters allowing a single value (const or one-element enum) in the request template instead of exposing them as args")
+	flattenBodyDepth := flag.Int("flatten-body-depth", 0, "Flatten nested objects of JSON request bodies into args positioned at their dot-path, e.g. body.user.address.city, down to this many levels (0 disables)")
+	maxToolsPerConfig := flag.Int("max-tools-per-config", 0, "Split the output into numbered configurations of at most this many tools, grouped by tag, with an index file (0 disables)")
+	sharedSchemas := flag.Bool("shared-schemas", false, "Define object properties repeated across args once in a schemas section referenced by the args")
+	emitInputSchema := flag.Bool("emit-input-schema", false, "Add the JSON Schema of the args of every tool as inputSchema, for MCP servers other than Higress")
+	examplesInDescription := flag.Bool("examples-in-description", false, "Append the examples of arguments to their descriptions")
+	inferFormats := flag.Bool("infer-formats", false, "Infer formats and descriptions of string arguments named *_id, *_at or *_url when the spec omits them")
+	emitPrompts := flag.Bool("emit-prompts", false, "Generate MCP prompts from the request examples of operations")
+	emitEvents := flag.Bool("emit-events", false, "Describe the callbacks and webhooks of the specification in an events section")
+	emitTests := flag.Bool("emit-tests", false, "Write sample tools/call requests for each tool next to the output, for smoke testing the server")
+	emitMetadata := flag.Bool("emit-metadata", false, "Add a metadata block with the generator version and conversion options to the output")
+	reportFile := flag.String("report", "", "Path to a JSON (or .yaml) report of the conversion listing the generated tools and their operations, the skipped operations, the warnings and timings")
+	dryRun := flag.Bool("dry-run", false, "Convert without writing the output file and print a summary of the conversion")
+	showVersion := flag.Bool("version", false, "Print the version and exit")
+	failOnWarning := flag.String("fail-on-warning", "", "Comma-separated warning categories that fail the conversion ("+strings.Join(converter.WarningCategories, ", ")+")")
+	warningsAsErrors := flag.Bool("warnings-as-errors", false, "Fail the conversion on any warning")
+	profile := flag.String("profile", "", "Profile providing default options ("+strings.Join(converter.ProfileNames(), ", ")+")")
+	includeTags := flag.String("include-tags", "", "Comma-separated tags of the operations to convert")
+	excludeTags := flag.String("exclude-tags", "", "Comma-separated tags of the operations to skip")
+	includePaths := flag.String("include-paths", "", "Comma-separated path patterns of the operations to convert (e.g. /users/*)")
+	includeMethods := flag.String("include-methods", "", "Comma-separated HTTP methods skipped by default (HEAD, OPTIONS, TRACE) to convert anyway")
+	excludePaths := flag.String("exclude-paths", "", "Comma-separated path patterns of the operations to skip")
+	includeOperations := flag.String("include-operations", "", "Comma-separated IDs of the operations to convert")
+	excludeOperations := flag.String("exclude-operations", "", "Comma-separated IDs of the operations to skip")
+	filterFile := flag.String("filter-file", "", "Path to a YAML file selecting the operations to convert; the filter flags take precedence")
+	configFile := flag.String("config", "", "Path to a YAML file providing flag values, keyed by flag name (default: "+scaffold.ProjectFile+" in the current directory, if present)")
+	manifestKind := flag.String("manifest", "", "Wrap the output in a Kubernetes manifest for Higress (wasmplugin or configmap)")
+	manifestName := flag.String("manifest-name", "", "Name of the manifest resource (default: derived from the server name)")
+	namespace := flag.String("namespace", manifest.DefaultNamespace, "Namespace of the manifest resource")
+	pluginURL := flag.String("plugin-url", manifest.DefaultPluginURL, "Image of the MCP server plugin in the WasmPlugin manifest")
+	pluginPhase := flag.String("plugin-phase", manifest.DefaultPhase, "Phase of the WasmPlugin (e.g. UNSPECIFIED_PHASE, AUTHN, AUTHZ, STATS)")
+	pluginPriority := flag.Int("plugin-priority", manifest.DefaultPriority, "Priority of the WasmPlugin within its phase")
+	matchDomains := flag.String("match-domains", "", "Comma-separated domains the WasmPlugin configuration applies to (default: all routes)")
+	matchServices := flag.String("match-services", "", "Comma-separated services the WasmPlugin configuration applies to")
+	matchIngresses := flag.String("match-ingresses", "", "Comma-separated ingresses the WasmPlugin configuration applies to")
+	sortOrder := flag.String("sort", "", "Order of the tools: alpha (by name, default), none (as declared in the spec) or tag (grouped by tag)")
+	keepArgOrder := flag.Bool("keep-arg-order", false, "Keep parameters in declaration order, followed by the request body args, instead of sorting args by name")
+	concurrency := flag.Int("concurrency", 0, "Number of operations converted in parallel (default: number of CPUs)")
+	noCache := flag.Bool("no-cache", false, "Download remote specifications and references without using the cache")
+	cacheTTL := flag.Duration("cache-ttl", parser.DefaultCacheTTL, "How long downloaded specifications and references are cached")
+	flag.BoolVar(&externalRefs, "allow-external-refs", false, "Resolve $ref references to other files and URLs")
+	incremental := flag.Bool("incremental", false, "Only regenerate the tools whose operations changed since the last conversion to --output, keeping the others unchanged, using a lock file of operation hashes")
+	mergePolicy := flag.String("merge-policy", converter.MergePolicyError, "How to resolve conflicts when merging several specs (error, prefer-first, prefer-last or rename-with-prefix)")
 
 	// Parse command-line flags
 	flag.Parse()
 
+	discovered := false
+	if *configFile == "" {
+		*configFile = findConfigFile()
+		discovered = *configFile != ""
+	}
+	if *configFile != "" {
+		if err := applyConfigFile(flag.CommandLine, *configFile, discovered); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if *noCache {
+		specCache = nil
+	} else {
+		specCache.TTL = *cacheTTL
+	}
+	if *transformScript != "" {
+		transformers = append(transformers, converter.ScriptTransformer{Path: *transformScript})
+	}
+
+	if *showVersion {
+		fmt.Println(version.String())
+		return
+	}
+
+	for _, dir := range inputDirs {
+		files, err := specFiles(dir)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		inputFiles = append(inputFiles, files...)
+	}
+
 	// Validate required flags
-	if *inputFile == "" {
+	if len(inputFiles) == 0 {
 		fmt.Println("Error: input file is required")
 		flag.Usage()
 		os.Exit(1)
 	}
 
-	if *outputFile == "" {
+	if *outputFile != "" && *batchDir != "" {
+		fmt.Println("Error: --output cannot be combined with --output-dir")
+		os.Exit(1)
+	}
+	if *outputFile == "" && *batchDir == "" && !*dryRun {
 		fmt.Println("Error: output file is required")
 		flag.Usage()
 		os.Exit(1)
 	}
 
-	// Create a new parser
-	p := parser.NewParser()
-
-	// Set validation option
-	p.SetValidation(*validate)
+	failOnWarnings := splitList(*failOnWarning)
+	for _, category := range failOnWarnings {
+		if !slices.Contains(converter.WarningCategories, category) {
+			fmt.Printf("Error: unknown warning category %q, expected one of %s\n", category, strings.Join(converter.WarningCategories, ", "))
+			os.Exit(1)
+		}
+	}
 
-	// Parse the OpenAPI specification
-	err := p.ParseFile(*inputFile)
+	credentialFrom, err := parseCredentialRefs(credentialRefs)
 	if err != nil {
-		fmt.Printf("Error parsing OpenAPI specification: %v\n", err)
+		fmt.Printf("Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	// Create a new converter
-	c := converter.NewConverter(p, models.ConvertOptions{
-		ServerName:     *serverName,
-		ToolNamePrefix: *toolNamePrefix,
-		TemplatePath:   *templateFile,
-	})
+	var fileFilter models.Filter
+	if *filterFile != "" {
+		var err error
+		if fileFilter, err = readFilterFile(*filterFile); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	options := models.ConvertOptions{
+		ServerName:              *serverName,
+		NameFrom:                *nameFrom,
+		BaseURL:                 *baseURL,
+		CredentialFrom:          credentialFrom,
+		ToolNamePrefix:          *toolNamePrefix,
+		PrefixByTag:             *prefixByTag,
+		TemplatePath:            *templateFile,
+		DescriptionFormat:       *descriptionFormat,
+		DescriptionTemplate:     *descriptionTemplate,
+		MaxDescriptionLength:    *maxDescriptionLength,
+		MaxArgDescriptionLength: *maxArgDescriptionLength,
+		DescriptionSummary:      *descriptionSummary,
+		Language:                *language,
+		DeriveAnnotations:       *deriveAnnotations,
+		EmitMetadata:            *emitMetadata,
+		RateLimitInDescription:  *rateLimitInDescription,
+		ResponseMaxFields:       *responseMaxFields,
+		ResponseMaxDepth:        *responseMaxDepth,
+		GetAsResources:          *getAsResources,
+		EmitPrompts:             *emitPrompts,
+		EmitEvents:              *emitEvents,
+		ExamplesInDescription:   *examplesInDescription,
+		SharedSchemas:           *sharedSchemas,
+		EmitInputSchema:         *emitInputSchema,
+		FoldConstants:           *foldConstants,
+		FlattenBodyDepth:        *flattenBodyDepth,
+		InferFormats:            *inferFormats,
+		FailOnWarnings:          failOnWarnings,
+		WarningsAsErrors:        *warningsAsErrors,
+		Sort:                    *sortOrder,
+		KeepArgOrder:            *keepArgOrder,
+		Concurrency:             *concurrency,
+		// Filter flags replace the corresponding lists of the filter file
+		Filter: models.Filter{
+			IncludeTags:       orDefault(splitList(*includeTags), fileFilter.IncludeTags),
+			ExcludeTags:       orDefault(splitList(*excludeTags), fileFilter.ExcludeTags),
+			IncludePaths:      orDefault(splitList(*includePaths), fileFilter.IncludePaths),
+			ExcludePaths:      orDefault(splitList(*excludePaths), fileFilter.ExcludePaths),
+			IncludeOperations: orDefault(splitList(*includeOperations), fileFilter.IncludeOperations),
+			ExcludeOperations: orDefault(splitList(*excludeOperations), fileFilter.ExcludeOperations),
+			IncludeMethods:    orDefault(splitList(*includeMethods), fileFilter.IncludeMethods),
+		},
+		Passthrough: models.Passthrough{
+			Schemes: splitList(*passthroughSchemes),
+			Tags:    splitList(*passthroughTags),
+		},
+		Profile: *profile,
+	}
 
-	// Convert the OpenAPI specification to an MCP configuration
-	config, err := c.Convert()
-	if err != nil {
-		fmt.Printf("Error converting OpenAPI specification: %v\n", err)
+	if *maxToolsPerConfig > 0 && *manifestKind != "" {
+		fmt.Println("Error: --max-tools-per-config cannot be combined with --manifest")
 		os.Exit(1)
 	}
+	if *batchDir != "" && (*maxToolsPerConfig > 0 || *manifestKind != "") {
+		fmt.Println("Error: --output-dir cannot be combined with --max-tools-per-config or --manifest")
+		os.Exit(1)
+	}
+	var previous *baseline
+	if *incremental {
+		if len(inputFiles) > 1 || *batchDir != "" || *maxToolsPerConfig > 0 || *manifestKind != "" {
+			fmt.Println("Error: --incremental requires a single input and a plain --output configuration")
+			os.Exit(1)
+		}
+		if *outputFile != "" {
+			if previous, err = readBaseline(*outputFile); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		} else {
+			previous = &baseline{}
+		}
+	}
+
+	// Convert each OpenAPI specification to an MCP configuration
+	sources := make([]converter.MergeSource, 0, len(inputFiles))
+	toolTags := make(map[string]string)
+	var lock *models.LockFile
+	report := newReport()
+	for _, inputFile := range inputFiles {
+		start := time.Now()
+		config, c, err := convertSpec(inputFile, *validate, options, previous)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		addSpec(report, inputFile, config, c, time.Since(start))
+		maps.Copy(toolTags, c.ToolTags())
+		if *incremental {
+			lock = c.LockFile()
+		}
+		if *dryRun {
+			printSummary(os.Stdout, inputFile, config, c)
+		} else {
+			printWarnings(inputFile, c)
+		}
+		sources = append(sources, converter.MergeSource{
+			Name:   strings.TrimSuffix(filepath.Base(inputFile), filepath.Ext(inputFile)),
+			Config: config,
+		})
+	}
+
+	if *reportFile != "" {
+		if err := writeReport(*reportFile, report); err != nil {
+			fmt.Printf("Error writing report: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// Write one configuration per specification and a tool set instead of merging them
+	if *batchDir != "" {
+		configs := make([]*models.MCPConfig, len(sources))
+		for i, source := range sources {
+			configs[i] = source.Config
+			if configs[i].Metadata != nil {
+				configs[i].Metadata.Sources = []string{inputFiles[i]}
+			}
+		}
+		name := *toolSetName
+		if name == "" {
+			name = filepath.Base(filepath.Clean(*batchDir))
+		}
+		toolSet, err := converter.BuildToolSet(name, configs, *mergePolicy)
+		if err != nil {
+			fmt.Printf("Error building tool set: %v\n", err)
+			os.Exit(1)
+		}
+		if *dryRun {
+			fmt.Printf("Tool set %s: %d servers\n", toolSet.Name, len(toolSet.ServerTools))
+			fmt.Println("Dry run: no output file was written")
+			return
+		}
+		file, err := writeBatch(*batchDir, configs, toolSet, *format, *emitTests)
+		if err != nil {
+			fmt.Printf("Error writing MCP configurations: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Successfully converted %d OpenAPI specifications to MCP configurations listed in: %s\n", len(configs), file)
+		return
+	}
+
+	// Merge the configurations if several specs were given
+	config := sources[0].Config
+	if len(sources) > 1 {
+		var err error
+		config, err = converter.MergeConfigs(sources, *mergePolicy)
+		if err != nil {
+			fmt.Printf("Error merging OpenAPI specifications: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if *dryRun {
+		if len(sources) > 1 {
+			fmt.Printf("Merged: %d tools\n", len(config.Tools))
+		}
+		fmt.Println("Dry run: no output file was written")
+		return
+	}
+
+	// Record the input specifications in the metadata block
+	if config.Metadata != nil {
+		config.Metadata.Sources = inputFiles
+	}
 
 	// Create the output directory if it doesn't exist
 	outputDir := filepath.Dir(*outputFile)
 	if outputDir != "" && outputDir != "." {
-		err = os.MkdirAll(outputDir, 0755)
+		err := os.MkdirAll(outputDir, 0755)
 		if err != nil {
 			fmt.Printf("Error creating output directory: %v\n", err)
 			os.Exit(1)
 		}
 	}
 
-	// Write the MCP configuration to the output file
+	if *emitTests {
+		testsFile := suffixedFileName(*outputFile, "tests")
+		if err := writeDocument(testsFile, converter.BuildSmokeTests(config), *format); err != nil {
+			fmt.Printf("Error writing smoke tests: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Smoke tests written to: %s\n", testsFile)
+	}
+
+	// Split the configuration if it has too many tools
+	if *maxToolsPerConfig > 0 && len(config.Tools) > *maxToolsPerConfig {
+		indexFile, err := writeShards(*outputFile, config, *maxToolsPerConfig, toolTags, *format)
+		if err != nil {
+			fmt.Printf("Error writing MCP configurations: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Successfully converted OpenAPI specification to MCP configurations indexed in: %s\n", indexFile)
+		return
+	}
+
+	// Write the MCP configuration, wrapped in a manifest if requested.
+	// A plain configuration is streamed tool by tool rather than encoded in memory at once.
+	if *manifestKind != "" {
+		wrapped, err := manifest.Wrap(config, manifest.Options{
+			Kind:      *manifestKind,
+			Name:      *manifestName,
+			Namespace: *namespace,
+			PluginURL: *pluginURL,
+			Phase:     *pluginPhase,
+			Priority:  *pluginPriority,
+			Domains:   splitList(*matchDomains),
+			Services:  splitList(*matchServices),
+			Ingresses: splitList(*matchIngresses),
+		})
+		if err != nil {
+			fmt.Printf("Error creating manifest: %v\n", err)
+			os.Exit(1)
+		}
+		if err := writeDocument(*outputFile, wrapped, *format); err != nil {
+			fmt.Printf("Error writing MCP configuration: %v\n", err)
+			os.Exit(1)
+		}
+	} else if err := writeConfig(*outputFile, config, *format); err != nil {
+		fmt.Printf("Error writing MCP configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	if lock != nil {
+		lockFile := suffixedFileName(*outputFile, lockFileSuffix)
+		if err := writeDocument(lockFile, lock, *format); err != nil {
+			fmt.Printf("Error writing lock file: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	fmt.Printf("Successfully converted OpenAPI specification to MCP configuration: %s\n", *outputFile)
+}
+
+// convertFile parses an OpenAPI specification file and converts it to an MCP configuration,
+// printing the conversion warnings
+func convertFile(inputFile string, validate bool, options models.ConvertOptions) (*models.MCPConfig, error) {
+	config, c, err := convertSpec(inputFile, validate, options, nil)
+	if err != nil {
+		return nil, err
+	}
+	printWarnings(inputFile, c)
+	return config, nil
+}
+
+// convertSpec parses an OpenAPI specification file and converts it to an MCP configuration,
+// returning the converter for its warnings and skipped operations. A non-nil baseline makes
+// the conversion incremental.
+func convertSpec(inputFile string, validate bool, options models.ConvertOptions, previous *baseline) (*models.MCPConfig, *converter.Converter, error) {
+	// x-mcp-options can select the document order, so it is recorded unless the flags choose another sort
+	preserveOrder := options.Sort == "" || options.Sort == converter.SortNone
+	p, variables, err := parseSpec(inputFile, validate, preserveOrder)
+	if err != nil {
+		return nil, nil, err
+	}
+	if variables != nil {
+		options.ServerConfig = variables
+	}
+
+	// Convert the OpenAPI specification to an MCP configuration
+	c := converter.NewConverter(p, options)
+	c.AddTransformer(transformers...)
+	if previous != nil {
+		c.SetBaseline(previous.config, previous.lock)
+	}
+	config, err := c.Convert()
+	if err != nil {
+		return nil, nil, fmt.Errorf("converting OpenAPI specification %s: %w", inputFile, err)
+	}
+	if config.Server.BaseURL == "" {
+		return nil, nil, fmt.Errorf("OpenAPI specification %s has no servers, set the URL of the API with --base-url", inputFile)
+	}
+	return config, c, nil
+}
+
+// transformers are added to the converter of every spec; scripts can only be set on the command line
+var transformers []converter.ToolTransformer
+
+// specCache caches the specifications and remote references downloaded by parseSpec; nil disables caching
+var specCache = &parser.Cache{Dir: parser.DefaultCacheDir(), TTL: parser.DefaultCacheTTL}
+
+// externalRefs enables the $ref references of parseSpec to other files and URLs
+var externalRefs bool
+
+// parseSpec parses an OpenAPI specification or Postman collection file or URL, recording the order
+// of operations if preserveOrder is set. Collections are
+// converted to OpenAPI first, and their variables are returned for the server config.
+func parseSpec(inputFile string, validate, preserveOrder bool) (*parser.Parser, map[string]any, error) {
 	var data []byte
-	if *format == "json" {
-		data, err = json.MarshalIndent(config, "", "  ")
+	var err error
+	if parser.IsURL(inputFile) {
+		data, err = specCache.Fetch(inputFile)
+	} else {
+		data, err = os.ReadFile(inputFile)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading %s: %w", inputFile, err)
+	}
+
+	var variables map[string]any
+	if postman.IsCollection(data) {
+		result, err := postman.Convert(data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("converting Postman collection %s: %w", inputFile, err)
+		}
+		for _, warning := range result.Warnings {
+			fmt.Fprintf(os.Stderr, "Warning: %s: %s\n", inputFile, warning)
+		}
+		data, variables = result.Document, result.Config
+	}
+
+	p := parser.NewParser()
+	p.SetValidation(validate)
+	p.SetPreserveOrder(preserveOrder)
+	p.SetExternalRefs(externalRefs)
+	p.SetCache(specCache)
+	if err := p.ParseFrom(data, inputFile); err != nil {
+		return nil, nil, fmt.Errorf("parsing OpenAPI specification %s: %w", inputFile, err)
+	}
+	return p, variables, nil
+}
+
+// printWarnings prints the warnings of a conversion to stderr
+func printWarnings(inputFile string, c *converter.Converter) {
+	for _, warning := range c.Warnings() {
+		fmt.Fprintf(os.Stderr, "Warning: %s: %s\n", inputFile, warning)
+	}
+}
+
+// writeConfig streams an MCP configuration to a file
+func writeConfig(path string, config *models.MCPConfig, format string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := output.WriteConfig(file, config, format); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// writeShards splits a configuration into configurations of at most maxTools tools, written
+// next to path with a numbered suffix, and writes their index. It returns the index file.
+func writeShards(path string, config *models.MCPConfig, maxTools int, tags map[string]string, format string) (string, error) {
+	shards := converter.SplitConfig(config, maxTools, tags)
+	files := make([]string, len(shards))
+	for i, shard := range shards {
+		file := suffixedFileName(path, strconv.Itoa(i+1))
+		if err := writeConfig(file, shard.Config, format); err != nil {
+			return "", err
+		}
+		files[i] = filepath.Base(file)
+	}
+
+	indexFile := suffixedFileName(path, "index")
+	if err := writeDocument(indexFile, converter.BuildShardIndex(config.Server.Name, shards, files, maxTools), format); err != nil {
+		return "", err
+	}
+	return indexFile, nil
+}
+
+// suffixedFileName adds a suffix to the name of a file, before its extension, e.g. mcp-server-1.yaml
+func suffixedFileName(path, suffix string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "-" + suffix + ext
+}
+
+// writeDocument encodes a document such as a Kubernetes manifest to a file
+func writeDocument(path string, document any, format string) error {
+	var data []byte
+	if format == output.FormatJSON {
+		var err error
+		if data, err = json.MarshalIndent(document, "", "  "); err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
 	} else {
 		var buffer bytes.Buffer
 		encoder := yaml.NewEncoder(&buffer)
 		encoder.SetIndent(2)
-
-		if err := encoder.Encode(config); err != nil {
-			fmt.Printf("Error encoding YAML: %v\n", err)
-			return
+		if err := encoder.Encode(document); err != nil {
+			return fmt.Errorf("failed to encode YAML: %w", err)
 		}
 		data = buffer.Bytes()
 	}
-	if err != nil {
-		fmt.Printf("Error marshaling MCP configuration: %v\n", err)
-		os.Exit(1)
+	return os.WriteFile(path, data, 0644)
+}
+
+// splitList splits a comma-separated flag value, ignoring empty items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
 	}
+	return items
+}
 
-	err = os.WriteFile(*outputFile, data, 0644)
-	if err != nil {
-		fmt.Printf("Error writing MCP configuration: %v\n", err)
-		os.Exit(1)
+// orDefault returns a list, or the fallback if the list is empty
+func orDefault(list, fallback []string) []string {
+	if len(list) == 0 {
+		return fallback
+	}
+	return list
+}
+
+// parseCredentialRefs parses credential references of security schemes of the form ID=kind:value
+func parseCredentialRefs(refs []string) (map[string]models.CredentialSource, error) {
+	if len(refs) == 0 {
+		return nil, nil
+	}
+	sources := make(map[string]models.CredentialSource, len(refs))
+	for _, ref := range refs {
+		id, value, ok := strings.Cut(ref, "=")
+		if !ok || id == "" {
+			return nil, fmt.Errorf("invalid --credential-from %q, expected ID=env:NAME, ID=file:PATH or ID=secret:REF", ref)
+		}
+		source, err := converter.ParseCredentialSource(value)
+		if err != nil {
+			return nil, err
+		}
+		sources[id] = source
 	}
+	return sources, nil
+}
 
-	fmt.Printf("Successfully converted OpenAPI specification to MCP configuration: %s\n", *outputFile)
+// stringList is a flag that can be repeated to collect several values
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
 }
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/converter"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/output"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/version"
+)
+
+// newReport starts the report of a run written with --report
+func newReport() *models.Report {
+	return &models.Report{
+		Generator: models.GeneratorInfo{
+			Name:      version.Name,
+			Version:   version.Version,
+			Commit:    version.Commit,
+			BuildDate: version.BuildDate,
+		},
+		StartedAt: time.Now().UTC(),
+		Specs:     []models.SpecReport{},
+	}
+}
+
+// addSpec adds the conversion of a specification to a report
+func addSpec(report *models.Report, inputFile string, config *models.MCPConfig, c *converter.Converter, duration time.Duration) {
+	report.Specs = append(report.Specs, models.SpecReport{
+		Input:      inputFile,
+		Server:     config.Server.Name,
+		DurationMS: duration.Milliseconds(),
+		ConversionReport: models.ConversionReport{
+			Tools:    orEmpty(c.ToolSources()),
+			Warnings: orEmpty(c.Warnings()),
+			Skipped:  orEmpty(c.Skipped()),
+		},
+	})
+}
+
+// writeReport writes a report as YAML if its file has a YAML extension, and as JSON otherwise
+func writeReport(path string, report *models.Report) error {
+	report.DurationMS = time.Since(report.StartedAt).Milliseconds()
+	format := output.FormatJSON
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		format = output.FormatYAML
+	}
+	return writeDocument(path, report, format)
+}
+
+// orEmpty returns an empty list instead of nil, so reports have lists rather than nulls
+func orEmpty[T any](list []T) []T {
+	if list == nil {
+		return []T{}
+	}
+	return list
+}
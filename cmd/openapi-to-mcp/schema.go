@@ -0,0 +1,27 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/schema"
+)
+
+// runSchema implements the `schema` subcommand, which prints the JSON Schema of the MCP configuration format
+func runSchema(args []string) {
+	flags := flag.NewFlagSet("schema", flag.ExitOnError)
+	outputFile := flags.String("output", "", "Path to write the JSON Schema to (default: stdout)")
+	flags.Parse(args)
+
+	if *outputFile == "" {
+		os.Stdout.Write(schema.MCPConfig())
+		return
+	}
+
+	if err := os.WriteFile(*outputFile, schema.MCPConfig(), 0644); err != nil {
+		fmt.Printf("Error writing JSON Schema: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Successfully wrote MCP configuration JSON Schema: %s\n", *outputFile)
+}
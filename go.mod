@@ -7,7 +7,7 @@ toolchain go1.24.5
 require (
 	github.com/getkin/kin-openapi v0.118.0
 	github.com/stretchr/testify v1.10.0
-	golang.org/x/exp v0.0.0-20250717185816-542afb5b7346
+	golang.org/x/net v0.35.0
 	gopkg.in/yaml.v3 v3.0.1
 )
 
@@ -21,8 +21,5 @@ require (
 	github.com/mohae/deepcopy v0.0.0-20170929034955-c48cc78d4826 // indirect
 	github.com/perimeterx/marshmallow v1.1.4 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/tidwall/gjson v1.18.0 // indirect
-	github.com/tidwall/match v1.1.1 // indirect
-	github.com/tidwall/pretty v1.2.0 // indirect
 	gopkg.in/yaml.v2 v2.4.0 // indirect
 )
@@ -0,0 +1,195 @@
+// Package audit reviews MCP configurations for risky exposures of HTTP APIs.
+package audit
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+)
+
+// Severities of findings
+const (
+	SeverityHigh   = "high"
+	SeverityMedium = "medium"
+	SeverityLow    = "low"
+)
+
+// Rules checked by the audit
+const (
+	RuleUnconfirmedDelete   = "unconfirmed-delete"
+	RuleAdminEndpoint       = "admin-endpoint"
+	RuleMissingSecurity     = "missing-security"
+	RulePlaintextCredential = "plaintext-credential"
+)
+
+// penalties are the points a finding of each severity takes off the score
+var penalties = map[string]int{
+	SeverityHigh:   15,
+	SeverityMedium: 5,
+	SeverityLow:    2,
+}
+
+// severityOrder ranks severities from the most to the least serious
+var severityOrder = []string{SeverityHigh, SeverityMedium, SeverityLow}
+
+// Finding is a risky exposure found in a configuration
+type Finding struct {
+	Severity string `json:"severity"`
+	Rule     string `json:"rule"`
+	Tool     string `json:"tool,omitempty"`
+	Message  string `json:"message"`
+}
+
+func (f Finding) String() string {
+	if f.Tool == "" {
+		return fmt.Sprintf("%-6s [%s] %s", f.Severity, f.Rule, f.Message)
+	}
+	return fmt.Sprintf("%-6s [%s] %s: %s", f.Severity, f.Rule, f.Tool, f.Message)
+}
+
+// Report is the result of auditing a configuration
+type Report struct {
+	// Score is 100 for a configuration without findings, minus penalties per finding, and at least 0
+	Score    int            `json:"score"`
+	Counts   map[string]int `json:"counts"`
+	Findings []Finding      `json:"findings"`
+}
+
+// Summary returns a one-line summary of the report
+func (r Report) Summary() string {
+	return fmt.Sprintf("Score: %d/100 (%d high, %d medium, %d low)",
+		r.Score, r.Counts[SeverityHigh], r.Counts[SeverityMedium], r.Counts[SeverityLow])
+}
+
+// adminPattern matches path segments and snake_case or kebab-case words of administrative endpoints
+var adminPattern = regexp.MustCompile(`(?i)(^|[/_\-.])(admin|administration|internal|superuser|sudo)([/_\-.]|$)`)
+
+// camelAdminPattern matches camelCase words of administrative tool names
+var camelAdminPattern = regexp.MustCompile(`(^(admin|internal)|Admin|Internal|Superuser|Sudo)([A-Z0-9]|$)`)
+
+// credentialHeaders are headers that carry credentials
+var credentialHeaders = []string{"authorization", "proxy-authorization", "cookie", "x-api-key", "api-key", "x-auth-token"}
+
+// secretConfigPattern matches names of server config values holding secrets
+var secretConfigPattern = regexp.MustCompile(`(?i)(key|token|secret|password|passwd|credential)`)
+
+// Audit checks the tools and server settings of a configuration
+func Audit(config *models.MCPConfig) Report {
+	var findings []Finding
+	schemes := make(map[string]models.SecurityScheme)
+	for _, scheme := range config.Server.SecuritySchemes {
+		schemes[scheme.ID] = scheme
+		if isPlaintext(scheme.DefaultCredential) {
+			findings = append(findings, Finding{
+				Severity: SeverityHigh,
+				Rule:     RulePlaintextCredential,
+				Message:  fmt.Sprintf("security scheme %q has a plaintext default credential", scheme.ID),
+			})
+		}
+	}
+
+	for key, value := range config.Server.Config {
+		if text, ok := value.(string); ok && secretConfigPattern.MatchString(key) && isPlaintext(text) {
+			findings = append(findings, Finding{
+				Severity: SeverityHigh,
+				Rule:     RulePlaintextCredential,
+				Message:  fmt.Sprintf("server config value %q looks like a plaintext secret", key),
+			})
+		}
+	}
+
+	for _, tool := range config.Tools {
+		if len(config.Server.AllowTools) > 0 && !slices.Contains(config.Server.AllowTools, tool.Name) {
+			continue
+		}
+		findings = append(findings, auditTool(tool, schemes)...)
+	}
+
+	return newReport(findings)
+}
+
+// auditTool checks a single tool
+func auditTool(tool models.Tool, schemes map[string]models.SecurityScheme) []Finding {
+	var findings []Finding
+	add := func(severity, rule, format string, args ...any) {
+		findings = append(findings, Finding{Severity: severity, Rule: rule, Tool: tool.Name, Message: fmt.Sprintf(format, args...)})
+	}
+	request := tool.RequestTemplate
+
+	// Destructive tools should be marked so MCP clients ask the user for confirmation
+	if strings.EqualFold(request.Method, "DELETE") && tool.Annotations["destructiveHint"] != true {
+		add(SeverityHigh, RuleUnconfirmedDelete, "DELETE tool has no destructiveHint annotation asking clients for confirmation")
+	}
+
+	if adminPattern.MatchString(requestPath(request.URL)) || adminPattern.MatchString(tool.Name) || camelAdminPattern.MatchString(tool.Name) {
+		// Reading administrative data is less risky than changing it
+		severity := SeverityMedium
+		if method := strings.ToUpper(request.Method); method == "GET" || method == "HEAD" {
+			severity = SeverityLow
+		}
+		add(severity, RuleAdminEndpoint, "tool exposes an administrative endpoint %s %s", request.Method, request.URL)
+	}
+
+	switch {
+	case request.Security == nil:
+		add(SeverityMedium, RuleMissingSecurity, "tool has no security requirement")
+	case schemes[request.Security.ID].ID == "":
+		add(SeverityMedium, RuleMissingSecurity, "tool references undefined security scheme %q", request.Security.ID)
+	}
+
+	for _, header := range request.Headers {
+		if slices.Contains(credentialHeaders, strings.ToLower(header.Key)) && isPlaintext(header.Value) {
+			add(SeverityHigh, RulePlaintextCredential, "header %s has a plaintext value", header.Key)
+		}
+	}
+	return findings
+}
+
+// requestPath strips the scheme and host from a request URL
+func requestPath(url string) string {
+	if _, rest, ok := strings.Cut(url, "://"); ok {
+		if i := strings.Index(rest, "/"); i >= 0 {
+			return rest[i:]
+		}
+		return ""
+	}
+	return url
+}
+
+// isPlaintext checks if a credential is a literal value rather than a template reference
+func isPlaintext(value string) bool {
+	value = strings.TrimSpace(value)
+	return value != "" && !strings.Contains(value, "{{") && !strings.HasPrefix(value, "${")
+}
+
+// newReport sorts findings by severity and computes the score
+func newReport(findings []Finding) Report {
+	sort.SliceStable(findings, func(i, j int) bool {
+		si, sj := slices.Index(severityOrder, findings[i].Severity), slices.Index(severityOrder, findings[j].Severity)
+		if si != sj {
+			return si < sj
+		}
+		if findings[i].Rule != findings[j].Rule {
+			return findings[i].Rule < findings[j].Rule
+		}
+		if findings[i].Tool != findings[j].Tool {
+			return findings[i].Tool < findings[j].Tool
+		}
+		return findings[i].Message < findings[j].Message
+	})
+
+	report := Report{Score: 100, Counts: make(map[string]int), Findings: findings}
+	for _, severity := range severityOrder {
+		report.Counts[severity] = 0
+	}
+	for _, finding := range findings {
+		report.Counts[finding.Severity]++
+		report.Score -= penalties[finding.Severity]
+	}
+	report.Score = max(report.Score, 0)
+	return report
+}
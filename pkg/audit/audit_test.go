@@ -0,0 +1,95 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAudit(t *testing.T) {
+	secured := &models.ToolSecurityRequirement{ID: "bearer"}
+	config := &models.MCPConfig{
+		Server: models.ServerConfig{
+			Name: "admin-api",
+			Config: map[string]any{
+				"apiKey":  "sk-live-123",
+				"token":   "{{.config.token}}",
+				"timeout": "30s",
+			},
+			SecuritySchemes: []models.SecurityScheme{
+				{ID: "bearer", Type: "http", Scheme: "bearer", DefaultCredential: "{{.config.token}}"},
+				{ID: "basic", Type: "http", Scheme: "basic", DefaultCredential: "admin:admin"},
+			},
+		},
+		Tools: []models.Tool{
+			{
+				Name:            "deleteUser",
+				RequestTemplate: models.RequestTemplate{URL: "/users/{id}", Method: "DELETE", Security: secured},
+			},
+			{
+				Name:            "deleteOrder",
+				Annotations:     map[string]any{"destructiveHint": true},
+				RequestTemplate: models.RequestTemplate{URL: "/orders/{id}", Method: "DELETE", Security: secured},
+			},
+			{
+				Name:            "listAuditLogs",
+				RequestTemplate: models.RequestTemplate{URL: "https://api.example.com/admin/logs", Method: "GET", Security: secured},
+			},
+			{
+				Name:            "adminResetPassword",
+				RequestTemplate: models.RequestTemplate{URL: "/users/{id}/password", Method: "POST", Security: &models.ToolSecurityRequirement{ID: "oauth"}},
+			},
+			{
+				Name: "getStatus",
+				RequestTemplate: models.RequestTemplate{URL: "/status", Method: "GET", Headers: []models.Header{
+					{Key: "Authorization", Value: "Bearer abc"},
+					{Key: "Accept", Value: "application/json"},
+				}},
+			},
+		},
+	}
+
+	report := Audit(config)
+	assert.Equal(t, []Finding{
+		{Severity: SeverityHigh, Rule: RulePlaintextCredential, Message: `security scheme "basic" has a plaintext default credential`},
+		{Severity: SeverityHigh, Rule: RulePlaintextCredential, Message: `server config value "apiKey" looks like a plaintext secret`},
+		{Severity: SeverityHigh, Rule: RulePlaintextCredential, Tool: "getStatus", Message: "header Authorization has a plaintext value"},
+		{Severity: SeverityHigh, Rule: RuleUnconfirmedDelete, Tool: "deleteUser", Message: "DELETE tool has no destructiveHint annotation asking clients for confirmation"},
+		{Severity: SeverityMedium, Rule: RuleAdminEndpoint, Tool: "adminResetPassword", Message: "tool exposes an administrative endpoint POST /users/{id}/password"},
+		{Severity: SeverityMedium, Rule: RuleMissingSecurity, Tool: "adminResetPassword", Message: `tool references undefined security scheme "oauth"`},
+		{Severity: SeverityMedium, Rule: RuleMissingSecurity, Tool: "getStatus", Message: "tool has no security requirement"},
+		{Severity: SeverityLow, Rule: RuleAdminEndpoint, Tool: "listAuditLogs", Message: "tool exposes an administrative endpoint GET https://api.example.com/admin/logs"},
+	}, report.Findings)
+	assert.Equal(t, 23, report.Score)
+	assert.Equal(t, "Score: 23/100 (4 high, 3 medium, 1 low)", report.Summary())
+}
+
+func TestAuditAllowTools(t *testing.T) {
+	config := &models.MCPConfig{
+		Server: models.ServerConfig{
+			Name:            "api",
+			AllowTools:      []string{"getStatus"},
+			SecuritySchemes: []models.SecurityScheme{{ID: "key", Type: "apiKey", In: "header", Name: "X-API-Key"}},
+		},
+		Tools: []models.Tool{
+			{Name: "getStatus", RequestTemplate: models.RequestTemplate{URL: "/status", Method: "GET", Security: &models.ToolSecurityRequirement{ID: "key"}}},
+			{Name: "deleteAll", RequestTemplate: models.RequestTemplate{URL: "/", Method: "DELETE"}},
+		},
+	}
+
+	// Tools that are not allowed aren't exposed, so they are not audited
+	report := Audit(config)
+	assert.Empty(t, report.Findings)
+	assert.Equal(t, 100, report.Score)
+}
+
+func TestScoreFloor(t *testing.T) {
+	var findings []Finding
+	for range 10 {
+		findings = append(findings, Finding{Severity: SeverityHigh, Rule: RuleUnconfirmedDelete})
+	}
+	report := newReport(findings)
+	assert.Equal(t, 0, report.Score)
+	assert.Equal(t, 10, report.Counts[SeverityHigh])
+}
@@ -0,0 +1,71 @@
+package converter
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Standard MCP tool annotation keys
+const (
+	AnnotationReadOnlyHint    = "readOnlyHint"
+	AnnotationDestructiveHint = "destructiveHint"
+	AnnotationIdempotentHint  = "idempotentHint"
+)
+
+// annotationsExtension lets spec authors override tool annotations per operation
+const annotationsExtension = "x-mcp-annotations"
+
+// annotationsField is the operation field passing annotations through to the tool
+const annotationsField = "annotations"
+
+// deriveAnnotations returns the standard MCP tool annotations implied by the semantics of an HTTP method
+func deriveAnnotations(method string) map[string]any {
+	switch strings.ToUpper(method) {
+	case "GET", "HEAD":
+		return map[string]any{AnnotationReadOnlyHint: true}
+	case "DELETE":
+		return map[string]any{AnnotationDestructiveHint: true}
+	case "PUT":
+		return map[string]any{AnnotationIdempotentHint: true}
+	}
+	return nil
+}
+
+// operationAnnotations returns a copy of the annotations field of an operation,
+// which the loader keeps with the extensions as it is not part of OpenAPI
+func operationAnnotations(operation *openapi3.Operation) (map[string]any, error) {
+	annotations := make(map[string]any)
+	value, ok := operation.Extensions[annotationsField]
+	if !ok {
+		return annotations, nil
+	}
+	fields, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("annotations must be an object, got %T", value)
+	}
+	maps.Copy(annotations, fields)
+	return annotations, nil
+}
+
+// applyAnnotations adds derived annotations (when enabled), the rate limit and x-mcp-annotations
+// overrides to the annotations passed through from the spec
+func (c *Converter) applyAnnotations(annotations map[string]any, method string, operation *openapi3.Operation) {
+	if c.options.DeriveAnnotations {
+		for key, value := range deriveAnnotations(method) {
+			if _, ok := annotations[key]; !ok {
+				annotations[key] = value
+			}
+		}
+	}
+	if limit := rateLimit(operation); limit != nil {
+		annotations[AnnotationRateLimit] = limit
+	}
+	if overrides, ok := operation.Extensions[annotationsExtension].(map[string]any); ok {
+		for key, value := range overrides {
+			annotations[key] = value
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package converter
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+)
+
+// cacheExtension declares the cache policy of an operation, e.g.
+//
+//	x-mcp-cache:
+//	  ttl: 5m
+//	  keyArgs: [city]
+const cacheExtension = "x-mcp-cache"
+
+// cachePolicy reads the x-mcp-cache extension of an operation.
+// The TTL is given in seconds or as a duration such as "5m".
+func cachePolicy(operation *openapi3.Operation, args []models.Arg) (*models.CachePolicy, error) {
+	raw, ok := operation.Extensions[cacheExtension]
+	if !ok {
+		return nil, nil
+	}
+	extension, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%s must be an object", cacheExtension)
+	}
+
+	policy := &models.CachePolicy{}
+	switch ttl := extension["ttl"].(type) {
+	case float64:
+		policy.TTL = int(ttl)
+	case string:
+		duration, err := time.ParseDuration(ttl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s ttl %q: %w", cacheExtension, ttl, err)
+		}
+		policy.TTL = int(duration.Seconds())
+	default:
+		return nil, fmt.Errorf("%s requires a ttl", cacheExtension)
+	}
+	if policy.TTL <= 0 {
+		return nil, fmt.Errorf("%s ttl must be positive", cacheExtension)
+	}
+
+	keyArgs, _ := extension["keyArgs"].([]any)
+	for _, keyArg := range keyArgs {
+		name := fmt.Sprint(keyArg)
+		if !hasArg(args, name) {
+			return nil, fmt.Errorf("%s key argument %q is not an argument of the tool", cacheExtension, name)
+		}
+		policy.KeyArgs = append(policy.KeyArgs, name)
+	}
+	return policy, nil
+}
+
+// hasArg checks if an argument with the given name exists
+func hasArg(args []models.Arg, name string) bool {
+	for _, arg := range args {
+		if arg.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+package converter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCachePolicy(t *testing.T) {
+	args := []models.Arg{{Name: "city"}, {Name: "units"}}
+
+	tests := []struct {
+		name      string
+		extension any
+		expected  *models.CachePolicy
+		wantErr   string
+	}{
+		{name: "No extension"},
+		{name: "TTL in seconds", extension: map[string]any{"ttl": float64(30)}, expected: &models.CachePolicy{TTL: 30}},
+		{
+			name:      "Duration and key arguments",
+			extension: map[string]any{"ttl": "1h", "keyArgs": []any{"city"}},
+			expected:  &models.CachePolicy{TTL: 3600, KeyArgs: []string{"city"}},
+		},
+		{name: "Missing TTL", extension: map[string]any{"keyArgs": []any{"city"}}, wantErr: "x-mcp-cache requires a ttl"},
+		{name: "Invalid duration", extension: map[string]any{"ttl": "soon"}, wantErr: `invalid x-mcp-cache ttl "soon": time: invalid duration "soon"`},
+		{name: "Negative TTL", extension: map[string]any{"ttl": float64(-1)}, wantErr: "x-mcp-cache ttl must be positive"},
+		{
+			name:      "Unknown key argument",
+			extension: map[string]any{"ttl": float64(60), "keyArgs": []any{"country"}},
+			wantErr:   `x-mcp-cache key argument "country" is not an argument of the tool`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			operation := &openapi3.Operation{Extensions: map[string]any{}}
+			if tc.extension != nil {
+				operation.Extensions[cacheExtension] = tc.extension
+			}
+			policy, err := cachePolicy(operation, args)
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, policy)
+		})
+	}
+}
+
+func TestTemplateCachePolicy(t *testing.T) {
+	templatePath := filepath.Join(t.TempDir(), "template.yaml")
+	assert.NoError(t, os.WriteFile(templatePath, []byte("tools:\n  cache:\n    ttl: 60\n"), 0644))
+
+	p := parser.NewParser()
+	assert.NoError(t, p.ParseFile("../../test/cache.json"))
+	config, err := NewConverter(p, models.ConvertOptions{TemplatePath: templatePath}).Convert()
+	assert.NoError(t, err)
+
+	policies := make(map[string]*models.CachePolicy)
+	for _, tool := range config.Tools {
+		policies[tool.Name] = tool.Cache
+	}
+	// The template only fills in GET tools without their own policy
+	assert.Equal(t, &models.CachePolicy{TTL: 600, KeyArgs: []string{"city"}}, policies["getForecast"])
+	assert.Equal(t, &models.CachePolicy{TTL: 3600}, policies["listStations"])
+	assert.Equal(t, &models.CachePolicy{TTL: 60}, policies["getConditions"])
+	assert.Nil(t, policies["subscribeAlerts"])
+}
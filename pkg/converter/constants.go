@@ -0,0 +1,80 @@
+package converter
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+)
+
+// constantParameter is a required parameter allowing a single value, such as an API version
+type constantParameter struct {
+	name  string
+	in    string
+	value string
+}
+
+// constantParameters returns the required path, query and header parameters whose schema allows
+// a single scalar value, with const or a one-element enum, in declaration order
+func constantParameters(parameters openapi3.Parameters) []constantParameter {
+	var constants []constantParameter
+	for _, paramRef := range parameters {
+		param := paramRef.Value
+		if param == nil || !param.Required || param.Schema == nil || param.Schema.Value == nil {
+			continue
+		}
+		switch param.In {
+		case openapi3.ParameterInPath, openapi3.ParameterInQuery, openapi3.ParameterInHeader:
+		default:
+			continue
+		}
+		if value, ok := constantValue(param.Schema.Value); ok {
+			constants = append(constants, constantParameter{name: param.Name, in: param.In, value: value})
+		}
+	}
+	return constants
+}
+
+// constantValue returns the single scalar value allowed by a schema
+func constantValue(schema *openapi3.Schema) (string, bool) {
+	var value any
+	if constant, ok := schema.Extensions["const"]; ok {
+		value = constant
+	} else if len(schema.Enum) == 1 {
+		value = schema.Enum[0]
+	} else {
+		return "", false
+	}
+	switch value.(type) {
+	case string, bool, float64, int, int64:
+		return formatValue(value), true
+	}
+	return "", false
+}
+
+// foldConstants removes the args of constant parameters from a tool and sets their value in its
+// request template: in the URL for path and query parameters, and as headers
+func foldConstants(tool *models.Tool, constants []constantParameter) {
+	for _, constant := range constants {
+		tool.Args = slices.DeleteFunc(tool.Args, func(arg models.Arg) bool {
+			return arg.Name == constant.name && arg.Position == constant.in
+		})
+
+		template := &tool.RequestTemplate
+		switch constant.in {
+		case openapi3.ParameterInPath:
+			template.URL = strings.ReplaceAll(template.URL, "{"+constant.name+"}", url.PathEscape(constant.value))
+		case openapi3.ParameterInQuery:
+			separator := "?"
+			if strings.Contains(template.URL, "?") {
+				separator = "&"
+			}
+			template.URL += separator + url.QueryEscape(constant.name) + "=" + url.QueryEscape(constant.value)
+		case openapi3.ParameterInHeader:
+			template.Headers = append(template.Headers, models.Header{Key: constant.name, Value: constant.value})
+		}
+	}
+}
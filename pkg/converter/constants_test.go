@@ -0,0 +1,33 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConstantValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		schema   *openapi3.Schema
+		expected string
+		ok       bool
+	}{
+		{name: "const", schema: &openapi3.Schema{Extensions: map[string]any{"const": "2024-01-01"}}, expected: "2024-01-01", ok: true},
+		{name: "single enum value", schema: &openapi3.Schema{Enum: []any{2.0}}, expected: "2", ok: true},
+		{name: "large number", schema: &openapi3.Schema{Extensions: map[string]any{"const": 1000000.0}}, expected: "1000000", ok: true},
+		{name: "boolean", schema: &openapi3.Schema{Enum: []any{true}}, expected: "true", ok: true},
+		{name: "several enum values", schema: &openapi3.Schema{Enum: []any{"asc", "desc"}}},
+		{name: "object", schema: &openapi3.Schema{Extensions: map[string]any{"const": map[string]any{"a": 1.0}}}},
+		{name: "no constant", schema: &openapi3.Schema{Type: "string"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			value, ok := constantValue(tc.schema)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.expected, value)
+		})
+	}
+}
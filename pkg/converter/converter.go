@@ -1,33 +1,51 @@
 package converter
 
 import (
-	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
 	"slices"
 	"sort"
 	"strings"
+	"text/template"
 
 	"github.com/getkin/kin-openapi/openapi3"
-	"github.com/tidwall/gjson"
 	"gopkg.in/yaml.v3"
 
 	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
 	"github.com/higress-group/openapi-to-mcpserver/pkg/parser"
 )
 
+// defaultCredentialExtension sets the default credential of a security scheme
+const defaultCredentialExtension = "x-mcp-default-credential"
+
 // Converter represents an OpenAPI to MCP converter
 type Converter struct {
-	parser  *parser.Parser
-	options models.ConvertOptions
+	parser        *parser.Parser
+	options       models.ConvertOptions
+	warnings      []models.Warning
+	skipped       []models.SkippedOperation
+	tags          map[string]string            // First tag of the operation of each tool
+	sources       map[string]models.ToolSource // Operation of each tool
+	operationTags map[string][]string          // Tags of the operation of each tool, until tools are transformed
+	report        []models.ToolSource          // Operations of the tools of the last conversion, in tool order
+	prepared      bool                         // Whether the options are resolved, see prepare
+
+	descriptionTemplate *template.Template
+	transformers        []ToolTransformer
+	describedSchemas    map[*openapi3.Schema]string // Path of the structures described in the current response
+
+	incremental  bool              // Whether operations are hashed, see SetBaseline
+	baseline     *models.MCPConfig // Previous configuration of an incremental conversion
+	baselineLock *models.LockFile  // Operation hashes of the previous configuration
+	documentTree map[string]any    // Document decoded as generic JSON values, for hashing operations
+	hashes       map[string]string // Operation hash of each tool, by tool name
+	settingsHash string            // Hash of the settings applying to every tool, see hashSettings
 }
 
 // NewConverter creates a new OpenAPI to MCP converter
 func NewConverter(parser *parser.Parser, options models.ConvertOptions) *Converter {
 	// Set default values if not provided
-	if options.ServerName == "" {
-		options.ServerName = "openapi-server"
-	}
 	if options.ServerConfig == nil {
 		options.ServerConfig = make(map[string]any)
 	}
@@ -38,23 +56,33 @@ func NewConverter(parser *parser.Parser, options models.ConvertOptions) *Convert
 	}
 }
 
+// ConvertWithReport converts an OpenAPI document to an MCP configuration and returns the
+// report of the conversion, which is also returned when warnings fail the conversion
+func (c *Converter) ConvertWithReport() (*models.MCPConfig, *models.ConversionReport, error) {
+	config, err := c.Convert()
+	return config, &models.ConversionReport{Tools: c.report, Warnings: c.warnings, Skipped: c.skipped}, err
+}
+
 // Convert converts an OpenAPI document to an MCP configuration
 func (c *Converter) Convert() (*models.MCPConfig, error) {
-	if c.parser.GetDocument() == nil {
-		return nil, fmt.Errorf("no OpenAPI document loaded")
-	}
+	c.warnings = nil
+	c.skipped = nil
+	c.report = nil
 
-	var baseURL string
-	doc := c.parser.GetDocument()
-	if servers := doc.Servers; len(servers) > 0 {
-		baseURL = servers[0].URL
+	if err := c.prepare(); err != nil {
+		return nil, err
+	}
+	if err := c.checkSort(); err != nil {
+		return nil, err
 	}
-	name := "openapi-server"
-	if c.options.ServerName != "" {
-		name = c.options.ServerName
+
+	baseURL, err := c.baseURL()
+	if err != nil {
+		return nil, err
 	}
-	if doc.Info != nil {
-		name = doc.Info.Title + " - " + doc.Info.Description
+	name, err := c.serverName()
+	if err != nil {
+		return nil, err
 	}
 	// Create the MCP configuration
 	config := &models.MCPConfig{
@@ -78,28 +106,65 @@ func (c *Converter) Convert() (*models.MCPConfig, error) {
 					Scheme: scheme.Scheme,
 					In:     scheme.In,
 					Name:   scheme.Name,
-					// DefaultCredential is not directly available in OpenAPI SecurityScheme,
-					// it's an extension for MCP. User can set it via template or manually.
+					// DefaultCredential is not available in OpenAPI SecurityScheme; it can be set
+					// with the x-mcp-default-credential extension, a template or manually.
+				}
+				if credential, ok := scheme.Extensions[defaultCredentialExtension].(string); ok {
+					mcpScheme.DefaultCredential = credential
 				}
 				config.Server.SecuritySchemes = append(config.Server.SecuritySchemes, mcpScheme)
 			}
 		}
 		// Sort security schemes by ID for consistent output
-		sort.Slice(config.Server.SecuritySchemes, func(i, j int) bool {
-			return config.Server.SecuritySchemes[i].ID < config.Server.SecuritySchemes[j].ID
-		})
+		sortSecuritySchemes(config.Server.SecuritySchemes)
 	}
 
-	// Process each path and operation
-	for path, pathItem := range c.parser.GetPaths() {
-		operations := getOperations(pathItem)
-		for method, operation := range operations {
-			tool, err := c.convertOperation(path, method, operation)
-			if err != nil {
-				return nil, fmt.Errorf("failed to convert operation %s %s: %w", method, path, err)
+	// Convert the operations in parallel, collecting the results in a deterministic order
+	c.tags = make(map[string]string)
+	c.sources = make(map[string]models.ToolSource)
+	c.operationTags = make(map[string][]string)
+	c.hashes = nil
+	if c.incremental {
+		c.hashes = make(map[string]string)
+	}
+	if err := c.loadDocumentTree(); err != nil {
+		return nil, err
+	}
+	if err := c.hashSettings(); err != nil {
+		return nil, err
+	}
+	for _, result := range c.convertOperations(baseURL) {
+		if result.err != nil {
+			return nil, result.err
+		}
+		c.warnings = append(c.warnings, result.warnings...)
+		if result.resource != nil {
+			config.Resources = append(config.Resources, *result.resource)
+			continue
+		}
+		if result.tool != nil {
+			config.Tools = append(config.Tools, *result.tool)
+			c.tags[result.tool.Name] = result.tag
+			c.sources[result.tool.Name] = result.source
+			c.operationTags[result.tool.Name] = result.tags
+			if c.incremental {
+				c.hashes[result.tool.Name] = result.hash
 			}
-			config.Tools = append(config.Tools, *tool)
+			config.Prompts = append(config.Prompts, result.prompts...)
+			config.Events = append(config.Events, result.events...)
+		}
+	}
+	if c.options.EmitEvents {
+		webhooks, err := c.webhookEvents()
+		if err != nil {
+			return nil, err
 		}
+		config.Events = append(config.Events, webhooks...)
+	}
+	c.checkToolNames(config.Tools)
+	sortWarnings(c.warnings)
+	if err := c.promotedWarnings(); err != nil {
+		return nil, err
 	}
 
 	// Apply template if provided
@@ -109,15 +174,50 @@ func (c *Converter) Convert() (*models.MCPConfig, error) {
 			return nil, fmt.Errorf("failed to apply template: %w", err)
 		}
 	}
+	c.applyPassthrough(config.Tools, c.options.Passthrough)
+	if err := c.applyCredentialSources(config); err != nil {
+		return nil, err
+	}
+	if err := c.transformTools(config.Tools); err != nil {
+		return nil, err
+	}
+	c.restoreTools(config.Tools)
 
-	// Sort tools by name for consistent output
-	sort.Slice(config.Tools, func(i, j int) bool {
-		return config.Tools[i].Name < config.Tools[j].Name
-	})
+	// Sort tools for consistent output
+	c.orderTools(config.Tools, c.tags)
+	c.report = c.toolSources(config.Tools)
+	sortResources(config.Resources)
+	sortPrompts(config.Prompts)
+	sortEvents(config.Events)
+
+	if c.options.SharedSchemas {
+		shareSchemas(config, c.componentSignatures())
+	}
+	if c.options.EmitInputSchema {
+		addInputSchemas(config)
+	}
+
+	if c.options.EmitMetadata {
+		config.Metadata = c.buildMetadata()
+	}
 
 	return config, nil
 }
 
+// sortTools sorts tools by name
+func sortTools(tools []models.Tool) {
+	sort.Slice(tools, func(i, j int) bool {
+		return tools[i].Name < tools[j].Name
+	})
+}
+
+// sortSecuritySchemes sorts security schemes by ID
+func sortSecuritySchemes(schemes []models.SecurityScheme) {
+	sort.Slice(schemes, func(i, j int) bool {
+		return schemes[i].ID < schemes[j].ID
+	})
+}
+
 // applyTemplate applies a template to the generated configuration
 func (c *Converter) applyTemplate(config *models.MCPConfig) error {
 	// Read the template file
@@ -149,7 +249,7 @@ func (c *Converter) applyTemplate(config *models.MCPConfig) error {
 	}
 
 	// Apply tool template to all tools
-	if templateConfig.Tools.RequestTemplate != nil || templateConfig.Tools.ResponseTemplate != nil || templateConfig.Tools.Security != nil {
+	if templateConfig.Tools.RequestTemplate != nil || templateConfig.Tools.ResponseTemplate != nil || templateConfig.Tools.Security != nil || templateConfig.Tools.Cache != nil {
 		for i := range config.Tools {
 			// Apply request template
 			if templateConfig.Tools.RequestTemplate != nil {
@@ -197,8 +297,16 @@ func (c *Converter) applyTemplate(config *models.MCPConfig) error {
 			if templateConfig.Tools.Security != nil {
 				config.Tools[i].Security = templateConfig.Tools.Security
 			}
+
+			// Apply the cache policy to read-only tools that have none
+			if templateConfig.Tools.Cache != nil && config.Tools[i].Cache == nil && config.Tools[i].RequestTemplate.Method == "GET" {
+				config.Tools[i].Cache = templateConfig.Tools.Cache
+			}
 		}
 	}
+	if templateConfig.Tools.Passthrough != nil {
+		c.applyPassthrough(config.Tools, *templateConfig.Tools.Passthrough)
+	}
 
 	return nil
 }
@@ -239,24 +347,31 @@ func getOperations(pathItem *openapi3.PathItem) map[string]*openapi3.Operation {
 func (c *Converter) convertOperation(path, method string, operation *openapi3.Operation) (*models.Tool, error) {
 	// Generate a tool name
 	toolName := c.parser.GetOperationID(path, method, operation)
+	if c.options.PrefixByTag && len(operation.Tags) > 0 {
+		toolName = tagPrefixedName(operation.Tags[0], toolName)
+	}
 	if c.options.ToolNamePrefix != "" {
 		toolName = c.options.ToolNamePrefix + toolName
 	}
 
-	// 特殊地址处理
-	jsonPath := strings.ReplaceAll(path, ".", "\\.")
-	result := gjson.ParseBytes(c.parser.GetData()).Get("paths").Get(jsonPath).Get(method).Get("annotations")
-	annotations := make(map[string]any)
-	if result.Exists() {
-		if err := json.Unmarshal([]byte(result.Raw), &annotations); err != nil {
-			return nil, fmt.Errorf("failed to parse annotations for %s %s: %w", method, path, err)
-		}
+	annotations, err := operationAnnotations(operation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse annotations for %s %s: %w", method, path, err)
+	}
+	c.applyAnnotations(annotations, method, operation)
+
+	description, err := c.templateDescription(path, method, operation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe %s %s: %w", method, path, err)
+	}
+	if limit, ok := annotations[AnnotationRateLimit].(map[string]any); ok && c.options.RateLimitInDescription {
+		description = strings.TrimSpace(description + "\n\n" + rateLimitDescription(limit))
 	}
 
 	// Create the tool
 	tool := &models.Tool{
 		Name:        toolName,
-		Description: getDescription(operation),
+		Description: description,
 		Args:        []models.Arg{},
 		Annotations: annotations,
 	}
@@ -273,12 +388,25 @@ func (c *Converter) convertOperation(path, method string, operation *openapi3.Op
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert request body: %w", err)
 	}
+	// Body properties have no declaration order, so they are sorted by name
+	sort.Slice(bodyArgs, func(i, j int) bool {
+		return bodyArgs[i].Name < bodyArgs[j].Name
+	})
 	tool.Args = append(tool.Args, bodyArgs...)
 
 	// Sort arguments by name for consistent output
-	sort.Slice(tool.Args, func(i, j int) bool {
-		return tool.Args[i].Name < tool.Args[j].Name
-	})
+	if !c.options.KeepArgOrder {
+		sort.SliceStable(tool.Args, func(i, j int) bool {
+			return tool.Args[i].Name < tool.Args[j].Name
+		})
+	}
+
+	if c.options.InferFormats {
+		inferFromNames(tool.Args)
+	}
+	if c.options.ExamplesInDescription {
+		describeExamples(tool.Args)
+	}
 
 	// Create request template
 	requestTemplate, err := c.createRequestTemplate(path, method, operation)
@@ -286,6 +414,9 @@ func (c *Converter) convertOperation(path, method string, operation *openapi3.Op
 		return nil, fmt.Errorf("failed to create request template: %w", err)
 	}
 	tool.RequestTemplate = *requestTemplate
+	if c.options.FoldConstants {
+		foldConstants(tool, constantParameters(operation.Parameters))
+	}
 
 	// Create response template
 	responseTemplate, err := c.createResponseTemplate(operation)
@@ -294,14 +425,21 @@ func (c *Converter) convertOperation(path, method string, operation *openapi3.Op
 	}
 	tool.ResponseTemplate = *responseTemplate
 
+	// Read the cache policy
+	tool.Cache, err = cachePolicy(operation, tool.Args)
+	if err != nil {
+		return nil, err
+	}
+
 	return tool, nil
 }
 
-func (c *Converter) convertSchemaToArg(position string, rootPropName string, required []string, schema *openapi3.Schema) models.Arg {
+// convertSchemaToArg converts a schema to an argument; nested schemas are not converted beyond maxSchemaDepth
+func (c *Converter) convertSchemaToArg(position string, rootPropName string, required []string, schema *openapi3.Schema, depth int) models.Arg {
 	arg := models.Arg{
 		Name:        rootPropName,
 		Title:       schema.Title,
-		Description: schema.Description,
+		Description: c.argDescription(schema.Extensions, schema.Description),
 		Type:        schema.Type,
 		Required:    contains(required, rootPropName),
 		Position:    position, // Set position to "body" for request body parameters
@@ -311,6 +449,7 @@ func (c *Converter) convertSchemaToArg(position string, rootPropName string, req
 	// Handle enum values
 	if len(schema.Enum) > 0 {
 		arg.Enum = schema.Enum
+		describeEnum(&arg, schema)
 	}
 
 	// 默认值处理
@@ -318,12 +457,16 @@ func (c *Converter) convertSchemaToArg(position string, rootPropName string, req
 		arg.Default = schema.Default
 	}
 
+	arg.Examples = appendExample(nil, schema.Example)
+	applySchemaConstraints(&arg, schema)
+
+	if depth >= maxSchemaDepth {
+		return arg
+	}
+
 	if schema.Type == "array" {
-		if schema.MinItems > 0 {
-			arg.MinItems = schema.MinItems
-		}
 		if schema.Items != nil && schema.Items.Value != nil {
-			itemsArg := c.convertSchemaToArg(arg.Position, "", schema.Required, schema.Items.Value)
+			itemsArg := c.convertSchemaToArg(arg.Position, "", schema.Required, schema.Items.Value, depth+1)
 			arg.Items = &itemsArg
 		}
 	}
@@ -334,15 +477,41 @@ func (c *Converter) convertSchemaToArg(position string, rootPropName string, req
 			if propRef.Value == nil {
 				continue
 			}
-			propArg := c.convertSchemaToArg(arg.Position, propName, schema.Required, propRef.Value)
+			propArg := c.convertSchemaToArg(arg.Position, propName, schema.Required, propRef.Value, depth+1)
 			properties[propName] = propArg
 		}
 		arg.Properties = properties
+		arg.AdditionalProperties = c.mapValueArg(arg.Position, schema, depth)
 	}
 	return arg
 }
 
-func (c *Converter) convertPropertiesToArg(position string, schema *openapi3.Schema) map[string]models.Arg {
+// mapValueSchema returns the schema of the values of a typed map, given by additionalProperties
+func mapValueSchema(schema *openapi3.Schema) *openapi3.Schema {
+	if schema.Type != "object" && schema.Type != "" {
+		return nil
+	}
+	if value := schema.AdditionalProperties.Schema; value != nil && value.Value != nil {
+		return value.Value
+	}
+	return nil
+}
+
+// mapValueArg converts the values of a typed map to an argument
+func (c *Converter) mapValueArg(position string, schema *openapi3.Schema, depth int) *models.Arg {
+	value := mapValueSchema(schema)
+	if value == nil || depth+1 >= maxSchemaDepth {
+		return nil
+	}
+	arg := c.convertSchemaToArg(position, "", nil, value, depth+1)
+	return &arg
+}
+
+// convertPropertiesToArg converts the properties of a schema at the given depth to arguments
+func (c *Converter) convertPropertiesToArg(position string, schema *openapi3.Schema, depth int) map[string]models.Arg {
+	if depth >= maxSchemaDepth {
+		return nil
+	}
 	properties := make(map[string]models.Arg)
 	for propName, propRef := range schema.Properties {
 		if propRef.Value == nil {
@@ -352,7 +521,7 @@ func (c *Converter) convertPropertiesToArg(position string, schema *openapi3.Sch
 			Name:        propName,
 			Title:       propRef.Value.Title,
 			Type:        propRef.Value.Type,
-			Description: propRef.Value.Description,
+			Description: c.argDescription(propRef.Value.Extensions, propRef.Value.Description),
 			Required:    contains(schema.Required, propName),
 			Position:    position,
 			Enabled:     true,
@@ -361,6 +530,7 @@ func (c *Converter) convertPropertiesToArg(position string, schema *openapi3.Sch
 		// Handle enum values
 		if len(propRef.Value.Enum) > 0 {
 			arg.Enum = propRef.Value.Enum
+			describeEnum(&arg, propRef.Value)
 		}
 
 		// 默认值处理
@@ -368,18 +538,19 @@ func (c *Converter) convertPropertiesToArg(position string, schema *openapi3.Sch
 			arg.Default = propRef.Value.Default
 		}
 
+		arg.Examples = appendExample(nil, propRef.Value.Example)
+		applySchemaConstraints(&arg, propRef.Value)
+
 		// Handle array type
 		if propRef.Value.Type == "array" && propRef.Value.Items != nil && propRef.Value.Items.Value != nil {
 			arg.Items = &models.Arg{
 				Type:        propRef.Value.Items.Value.Type,
 				Title:       propRef.Value.Items.Value.Title,
-				Description: propRef.Value.Items.Value.Description,
-			}
-			if propRef.Value.Items.Value.MinItems > 0 {
-				arg.Items.MinItems = propRef.Value.Items.Value.MinItems
+				Description: c.argDescription(propRef.Value.Items.Value.Extensions, propRef.Value.Items.Value.Description),
 			}
+			applySchemaConstraints(arg.Items, propRef.Value.Items.Value)
 			if propRef.Value.Items.Value.Type == "object" && propRef.Value.Items.Value.Properties != nil {
-				arg.Items.Properties = c.convertPropertiesToArg(arg.Position, propRef.Value.Items.Value)
+				arg.Items.Properties = c.convertPropertiesToArg(arg.Position, propRef.Value.Items.Value, depth+2)
 			}
 			if propRef.Value.Items.Value.Default != nil {
 				arg.Items.Default = propRef.Value.Items.Value.Default
@@ -388,8 +559,9 @@ func (c *Converter) convertPropertiesToArg(position string, schema *openapi3.Sch
 
 		// Handle object type
 		if propRef.Value.Type == "object" && len(propRef.Value.Properties) > 0 {
-			arg.Properties = c.convertPropertiesToArg(arg.Position, propRef.Value)
+			arg.Properties = c.convertPropertiesToArg(arg.Position, propRef.Value, depth+1)
 		}
+		arg.AdditionalProperties = c.mapValueArg(arg.Position, propRef.Value, depth)
 
 		properties[propName] = arg
 	}
@@ -407,7 +579,7 @@ func (c *Converter) convertParameters(parameters openapi3.Parameters) ([]models.
 
 		arg := models.Arg{
 			Name:        param.Name,
-			Description: param.Description,
+			Description: c.argDescription(param.Extensions, param.Description),
 			Required:    param.Required,
 			Position:    param.In, // Set position based on parameter location (query, path, header, cookie)
 			Enabled:     true,
@@ -429,6 +601,7 @@ func (c *Converter) convertParameters(parameters openapi3.Parameters) ([]models.
 			// Handle enum values
 			if len(schema.Enum) > 0 {
 				arg.Enum = schema.Enum
+				describeEnum(&arg, schema)
 			}
 
 			// 默认值处理
@@ -436,6 +609,9 @@ func (c *Converter) convertParameters(parameters openapi3.Parameters) ([]models.
 				arg.Default = schema.Default
 			}
 
+			// Propagate validation constraints
+			applySchemaConstraints(&arg, schema)
+
 			// Handle array type
 			if schema.Type == "array" && schema.Items != nil && schema.Items.Value != nil {
 				arg.Items = &models.Arg{
@@ -445,13 +621,15 @@ func (c *Converter) convertParameters(parameters openapi3.Parameters) ([]models.
 					arg.Items.Title = schema.Items.Value.Title
 				}
 				if schema.Items.Value.Description != "" {
-					arg.Items.Description = schema.Items.Value.Description
+					arg.Items.Description = c.argDescription(schema.Items.Value.Extensions, schema.Items.Value.Description)
 				}
-				if schema.Items.Value.MinItems > 0 {
-					arg.Items.MinItems = schema.Items.Value.MinItems
+				if len(schema.Items.Value.Enum) > 0 {
+					arg.Items.Enum = schema.Items.Value.Enum
+					describeEnum(arg.Items, schema.Items.Value)
 				}
+				applySchemaConstraints(arg.Items, schema.Items.Value)
 				if schema.Items.Value.Type == "object" && schema.Items.Value.Properties != nil {
-					arg.Items.Properties = c.convertPropertiesToArg(arg.Position, schema.Items.Value)
+					arg.Items.Properties = c.convertPropertiesToArg(arg.Position, schema.Items.Value, 1)
 				}
 				if schema.Items.Value.Default != nil {
 					arg.Items.Default = schema.Items.Value.Default
@@ -460,16 +638,34 @@ func (c *Converter) convertParameters(parameters openapi3.Parameters) ([]models.
 
 			// Handle object type
 			if schema.Type == "object" && len(schema.Properties) > 0 {
-				arg.Properties = c.convertPropertiesToArg(arg.Position, schema)
+				arg.Properties = c.convertPropertiesToArg(arg.Position, schema, 0)
 			}
+			arg.AdditionalProperties = c.mapValueArg(arg.Position, schema, 0)
 		}
 
+		// Parameter-level examples come before the schema example
+		arg.Examples = parameterExamples(param)
+
 		args = append(args, arg)
 	}
 
 	return args, nil
 }
 
+// applySchemaConstraints copies the format and validation constraints of a schema to an argument
+func applySchemaConstraints(arg *models.Arg, schema *openapi3.Schema) {
+	arg.Format = schema.Format
+	arg.Pattern = schema.Pattern
+	arg.Minimum = schema.Min
+	arg.Maximum = schema.Max
+	arg.MinLength = schema.MinLength
+	arg.MaxLength = schema.MaxLength
+	arg.MinItems = schema.MinItems
+	arg.MaxItems = schema.MaxItems
+	arg.UniqueItems = schema.UniqueItems
+	arg.Nullable = schema.Nullable
+}
+
 // convertRequestBody converts an OpenAPI request body to MCP arguments
 func (c *Converter) convertRequestBody(requestBodyRef *openapi3.RequestBodyRef) ([]models.Arg, error) {
 	var args []models.Arg
@@ -480,34 +676,79 @@ func (c *Converter) convertRequestBody(requestBodyRef *openapi3.RequestBodyRef)
 
 	requestBody := requestBodyRef.Value
 
-	// Process each content type
-	for contentType, mediaType := range requestBody.Content {
-		if mediaType.Schema == nil || mediaType.Schema.Value == nil {
-			continue
-		}
-
-		schema := mediaType.Schema.Value
+	// Only the content type used by the request template is converted,
+	// so that specs offering several equivalent media types don't produce duplicate args
+	contentType := selectRequestContentType(requestBody.Content)
+	mediaType := requestBody.Content[contentType]
+	if mediaType == nil || mediaType.Schema == nil || mediaType.Schema.Value == nil {
+		return args, nil
+	}
 
-		// For JSON and form content types, convert the schema to arguments
-		if strings.Contains(contentType, "application/json") ||
-			strings.Contains(contentType, "application/x-www-form-urlencoded") {
+	schema := mediaType.Schema.Value
 
-			// For object type, convert each property to an argument
-			if schema.Type == "object" && len(schema.Properties) > 0 {
-				for propName, propRef := range schema.Properties {
-					if propRef.Value == nil {
-						continue
-					}
-					arg := c.convertSchemaToArg("body", propName, schema.Required, propRef.Value)
-					args = append(args, arg)
+	// For JSON, form and XML content types, convert the schema to arguments
+	if isJSONContentType(contentType) || isFormContentType(contentType) || isXMLContentType(contentType) {
+		// For object type, convert each property to an argument
+		if schema.Type == "object" && len(schema.Properties) > 0 {
+			for propName, propRef := range schema.Properties {
+				if propRef.Value == nil {
+					continue
 				}
+				arg := c.convertSchemaToArg("body", propName, schema.Required, propRef.Value, 0)
+				for _, example := range bodyExamples(mediaType, propName) {
+					arg.Examples = appendExample(arg.Examples, example)
+				}
+				args = append(args, arg)
 			}
 		}
 	}
 
+	if isJSONContentType(contentType) && c.options.FlattenBodyDepth > 0 {
+		args = flattenBodyArgs(args, c.options.FlattenBodyDepth)
+	}
 	return args, nil
 }
 
+// selectRequestContentType picks the request body content type used for the tool.
+// JSON is preferred, then URL-encoded forms, then XML, then the first content type in alphabetical order.
+func selectRequestContentType(content openapi3.Content) string {
+	if len(content) == 0 {
+		return ""
+	}
+	contentTypes := make([]string, 0, len(content))
+	for contentType := range content {
+		contentTypes = append(contentTypes, contentType)
+	}
+	sort.Strings(contentTypes)
+
+	for _, contentType := range contentTypes {
+		if isJSONContentType(contentType) {
+			return contentType
+		}
+	}
+	for _, contentType := range contentTypes {
+		if isFormContentType(contentType) {
+			return contentType
+		}
+	}
+	for _, contentType := range contentTypes {
+		if isXMLContentType(contentType) {
+			return contentType
+		}
+	}
+	return contentTypes[0]
+}
+
+// isJSONContentType checks if a content type carries a JSON payload
+func isJSONContentType(contentType string) bool {
+	return strings.Contains(contentType, "application/json") || strings.HasSuffix(strings.Split(contentType, ";")[0], "+json")
+}
+
+// isFormContentType checks if a content type is a URL-encoded form
+func isFormContentType(contentType string) bool {
+	return strings.Contains(contentType, "application/x-www-form-urlencoded")
+}
+
 func (c *Converter) allOfHandle(schemaRef *openapi3.SchemaRef) map[string]interface{} {
 	properties := make(map[string]interface{})
 	if schemaRef.Value.Type == "object" {
@@ -548,13 +789,21 @@ func (c *Converter) createRequestTemplate(path, method string, operation *openap
 			if securitySchemeFound {
 				break
 			}
+			schemeNames := make([]string, 0, len(securityRequirement))
 			for schemeName := range securityRequirement {
+				schemeNames = append(schemeNames, schemeName)
+			}
+			sort.Strings(schemeNames)
+			for _, schemeName := range schemeNames {
 				// In MCP, we just reference the scheme by ID.
 				// The actual application of security (e.g., adding headers)
 				// would be handled by the MCP server runtime based on this ID.
 				template.Security = &models.ToolSecurityRequirement{
 					ID: schemeName,
 				}
+				if scopes := securityRequirement[schemeName]; len(scopes) > 0 {
+					template.Security.Scopes = slices.Clone(scopes)
+				}
 				securitySchemeFound = true
 				break
 			}
@@ -563,13 +812,24 @@ func (c *Converter) createRequestTemplate(path, method string, operation *openap
 
 	// Add Content-Type header based on request body content type
 	if operation.RequestBody != nil && operation.RequestBody.Value != nil {
-		for contentType := range operation.RequestBody.Value.Content {
+		if contentType := selectRequestContentType(operation.RequestBody.Value.Content); contentType != "" {
 			// Add the Content-Type header
 			template.Headers = append(template.Headers, models.Header{
 				Key:   "Content-Type",
 				Value: contentType,
 			})
-			break // Just use the first content type
+
+			// Tell the MCP runtime how to serialize the body args
+			switch {
+			case isJSONContentType(contentType):
+				template.ArgsToJsonBody = true
+			case isFormContentType(contentType):
+				template.ArgsToFormBody = true
+			case isXMLContentType(contentType):
+				if schema := operation.RequestBody.Value.Content[contentType].Schema; schema != nil && schema.Value != nil {
+					template.Body = xmlBodyTemplate(schema)
+				}
+			}
 		}
 	}
 
@@ -607,6 +867,8 @@ func (c *Converter) createResponseTemplate(operation *openapi3.Operation) (*mode
 	prependBody.WriteString("## Response Structure\n\n")
 
 	// Process each content type
+	c.describedSchemas = make(map[*openapi3.Schema]string)
+	maxDepth := c.responseMaxDepth()
 	for contentType, mediaType := range successResponse.Content {
 		if mediaType.Schema == nil || mediaType.Schema.Value == nil {
 			continue
@@ -620,7 +882,7 @@ func (c *Converter) createResponseTemplate(operation *openapi3.Operation) (*mode
 			// Handle array type
 			prependBody.WriteString("- **items**: Array of items (Type: array)\n")
 			// Process array items recursively
-			c.processSchemaProperties(&prependBody, schema.Items.Value, "items", 1, 10)
+			c.processSchemaProperties(&prependBody, schema.Items.Value, "items", 1, maxDepth)
 		} else if schema.Type == "object" && len(schema.Properties) > 0 {
 			// Get property names and sort them alphabetically for consistent output
 			propNames := make([]string, 0, len(schema.Properties))
@@ -628,6 +890,7 @@ func (c *Converter) createResponseTemplate(operation *openapi3.Operation) (*mode
 				propNames = append(propNames, propName)
 			}
 			sort.Strings(propNames)
+			propNames, omitted := c.limitFields(propNames)
 
 			// Process properties in alphabetical order
 			for _, propName := range propNames {
@@ -637,21 +900,32 @@ func (c *Converter) createResponseTemplate(operation *openapi3.Operation) (*mode
 				}
 
 				// Write the property description
-				prependBody.WriteString(fmt.Sprintf("- **%s**: %s", propName, propRef.Value.Description))
+				prependBody.WriteString(fmt.Sprintf("- **%s**: %s", propName, c.formatDescription(c.localize(propRef.Value.Extensions, "description", propRef.Value.Description))))
 				if propRef.Value.Type != "" {
 					prependBody.WriteString(fmt.Sprintf(" (Type: %s)", propRef.Value.Type))
 				}
 				prependBody.WriteString("\n")
 
 				// Process nested properties recursively
-				c.processSchemaProperties(&prependBody, propRef.Value, propName, 1, 10)
+				c.processSchemaProperties(&prependBody, propRef.Value, propName, 1, maxDepth)
 			}
+			writeMoreFields(&prependBody, 0, omitted)
+		} else if mapValueSchema(schema) != nil {
+			// Handle map type
+			c.writeMapValues(&prependBody, schema, "", 0, maxDepth)
 		}
 	}
 
 	prependBody.WriteString("\n## Original Response\n\n")
-	template.PrependBody = prependBody.String()
 	template.PrependBody = ""
+	if c.options.ResponseMaxFields > 0 {
+		template.PrependBody = prependBody.String()
+	}
+
+	// XML responses are passed through unchanged, so the LLM is told what it receives
+	if contentType := responseContentType(operation); isXMLContentType(contentType) {
+		template.PrependBody = fmt.Sprintf("The response is an XML document (Content-Type: %s).\n\n", contentType) + template.PrependBody
+	}
 
 	return template, nil
 }
@@ -667,6 +941,9 @@ func (c *Converter) processSchemaProperties(prependBody *strings.Builder, schema
 
 	// Calculate indentation based on depth
 	indent := strings.Repeat("  ", depth)
+	if c.collapseSchema(prependBody, schema, path, depth) {
+		return
+	}
 
 	// Handle array type
 	if schema.Type == "array" && schema.Items != nil && schema.Items.Value != nil {
@@ -686,6 +963,7 @@ func (c *Converter) processSchemaProperties(prependBody *strings.Builder, schema
 				propNames = append(propNames, propName)
 			}
 			sort.Strings(propNames)
+			propNames, omitted := c.limitFields(propNames)
 
 			// Process each property
 			for _, propName := range propNames {
@@ -696,7 +974,7 @@ func (c *Converter) processSchemaProperties(prependBody *strings.Builder, schema
 
 				// Write the property description
 				propPath := fmt.Sprintf("%s[].%s", path, propName)
-				fmt.Fprintf(prependBody, "%s- **%s**: %s", indent, propPath, propRef.Value.Description)
+				fmt.Fprintf(prependBody, "%s- **%s**: %s", indent, propPath, c.formatDescription(c.localize(propRef.Value.Extensions, "description", propRef.Value.Description)))
 				if propRef.Value.Type != "" {
 					fmt.Fprintf(prependBody, " (Type: %s)", propRef.Value.Type)
 				}
@@ -705,6 +983,10 @@ func (c *Converter) processSchemaProperties(prependBody *strings.Builder, schema
 				// Process nested properties recursively
 				c.processSchemaProperties(prependBody, propRef.Value, propPath, depth+1, maxDepth)
 			}
+			writeMoreFields(prependBody, depth, omitted)
+		} else if mapValueSchema(arrayItemSchema) != nil {
+			// If array items are maps, describe their values
+			c.writeMapValues(prependBody, arrayItemSchema, path+"[]", depth, maxDepth)
 		} else if arrayItemSchema.Type != "" {
 			// If array items are not objects, just describe the array item type
 			fmt.Fprintf(prependBody, "%s- **%s[]**: Items of type %s\n", indent, path, arrayItemSchema.Type)
@@ -720,6 +1002,7 @@ func (c *Converter) processSchemaProperties(prependBody *strings.Builder, schema
 			propNames = append(propNames, propName)
 		}
 		sort.Strings(propNames)
+		propNames, omitted := c.limitFields(propNames)
 
 		// Process each property
 		for _, propName := range propNames {
@@ -730,7 +1013,7 @@ func (c *Converter) processSchemaProperties(prependBody *strings.Builder, schema
 
 			// Write the property description
 			propPath := fmt.Sprintf("%s.%s", path, propName)
-			fmt.Fprintf(prependBody, "%s- **%s**: %s", indent, propPath, propRef.Value.Description)
+			fmt.Fprintf(prependBody, "%s- **%s**: %s", indent, propPath, c.formatDescription(c.localize(propRef.Value.Extensions, "description", propRef.Value.Description)))
 			if propRef.Value.Type != "" {
 				fmt.Fprintf(prependBody, " (Type: %s)", propRef.Value.Type)
 			}
@@ -739,18 +1022,49 @@ func (c *Converter) processSchemaProperties(prependBody *strings.Builder, schema
 			// Process nested properties recursively
 			c.processSchemaProperties(prependBody, propRef.Value, propPath, depth+1, maxDepth)
 		}
+		writeMoreFields(prependBody, depth, omitted)
 	}
+
+	// Handle map type
+	c.writeMapValues(prependBody, schema, path, depth, maxDepth)
 }
 
-// getDescription returns a description for an operation
-func getDescription(operation *openapi3.Operation) string {
-	if operation.Description != "" {
-		return operation.Description
+// writeMapValues describes the values of a map-shaped schema as a {key} entry below path
+func (c *Converter) writeMapValues(prependBody *strings.Builder, schema *openapi3.Schema, path string, depth, maxDepth int) {
+	value := mapValueSchema(schema)
+	if value == nil || depth > maxDepth {
+		return
+	}
+
+	valuePath := "{key}"
+	if path != "" {
+		valuePath = path + ".{key}"
+	}
+	description := c.formatDescription(c.localize(value.Extensions, "description", value.Description))
+	if description == "" {
+		description = "Value of each map entry"
+	}
+	fmt.Fprintf(prependBody, "%s- **%s**: %s", strings.Repeat("  ", depth), valuePath, description)
+	if value.Type != "" {
+		fmt.Fprintf(prependBody, " (Type: %s)", value.Type)
+	}
+	prependBody.WriteString("\n")
+	c.processSchemaProperties(prependBody, value, valuePath, depth+1, maxDepth)
+}
+
+// baseURL returns the URL of the API: the BaseURL option if set, else the URL of the first server of the document
+func (c *Converter) baseURL() (string, error) {
+	if c.options.BaseURL == "" {
+		if servers := c.parser.GetDocument().Servers; len(servers) > 0 {
+			return servers[0].URL, nil
+		}
+		return "", nil
 	}
-	if operation.Summary != "" {
-		return operation.Summary
+	u, err := url.Parse(c.options.BaseURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("invalid base URL %q, expected an absolute URL such as https://api.example.com", c.options.BaseURL)
 	}
-	return operation.Description
+	return c.options.BaseURL, nil
 }
 
 // contains checks if a string slice contains a string
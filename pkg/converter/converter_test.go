@@ -20,6 +20,7 @@ func TestEndToEndConversion(t *testing.T) {
 		expectedOutput string
 		serverName     string
 		templatePath   string
+		options        models.ConvertOptions
 	}{
 		{
 			name:           "Petstore API",
@@ -76,6 +77,144 @@ func TestEndToEndConversion(t *testing.T) {
 			expectedOutput: "../../test/expected-allof-params-mcp.yaml",
       serverName:     "openapi-server",
 		},
+		{
+			name:           "Parameter Constraints API",
+			inputFile:      "../../test/param-constraints.json",
+			expectedOutput: "../../test/expected-param-constraints-mcp.yaml",
+			serverName:     "param-constraints-api",
+		},
+		{
+			name:           "Additional Properties API",
+			inputFile:      "../../test/additional-properties.json",
+			expectedOutput: "../../test/expected-additional-properties-mcp.yaml",
+			serverName:     "additional-properties-api",
+		},
+		{
+			name:           "Nested Body API",
+			inputFile:      "../../test/nested-body.json",
+			expectedOutput: "../../test/expected-nested-body-mcp.yaml",
+			serverName:     "nested-body-api",
+			options:        models.ConvertOptions{FlattenBodyDepth: 2, EmitPrompts: true},
+		},
+		{
+			name:           "Shared Schemas API",
+			inputFile:      "../../test/shared-schemas.json",
+			expectedOutput: "../../test/expected-shared-schemas-mcp.yaml",
+			serverName:     "shared-schemas-api",
+			options:        models.ConvertOptions{SharedSchemas: true},
+		},
+		{
+			name:           "Scoped Security API",
+			inputFile:      "../../test/scoped-security.json",
+			expectedOutput: "../../test/expected-scoped-security-mcp.yaml",
+			serverName:     "projects-api",
+			templatePath:   "../../test/passthrough-template.yaml",
+			options:        models.ConvertOptions{Passthrough: models.Passthrough{Schemes: []string{"bearerAuth"}}},
+		},
+		{
+			name:           "Fold Constants API",
+			inputFile:      "../../test/constants.json",
+			expectedOutput: "../../test/expected-constants-mcp.yaml",
+			serverName:     "storage-api",
+			options:        models.ConvertOptions{FoldConstants: true},
+		},
+		{
+			name:           "Enum Descriptions API",
+			inputFile:      "../../test/enum-descriptions.json",
+			expectedOutput: "../../test/expected-enum-descriptions-mcp.yaml",
+			serverName:     "orders-api",
+		},
+		{
+			name:           "Large Response API",
+			inputFile:      "../../test/large-response.json",
+			expectedOutput: "../../test/expected-large-response-mcp.yaml",
+			serverName:     "large-response-api",
+			options:        models.ConvertOptions{ResponseMaxFields: 4},
+		},
+		{
+			name:           "Events API",
+			inputFile:      "../../test/events.json",
+			expectedOutput: "../../test/expected-events-mcp.yaml",
+			serverName:     "events-api",
+			options:        models.ConvertOptions{EmitEvents: true},
+		},
+		{
+			name:           "Input Schemas API",
+			inputFile:      "../../test/shared-schemas.json",
+			expectedOutput: "../../test/expected-input-schema-mcp.yaml",
+			serverName:     "shared-schemas-api",
+			options:        models.ConvertOptions{SharedSchemas: true, EmitInputSchema: true},
+		},
+		{
+			name:           "Localized Descriptions API",
+			inputFile:      "../../test/i18n-descriptions.json",
+			expectedOutput: "../../test/expected-i18n-descriptions-mcp.yaml",
+			serverName:     "i18n-descriptions-api",
+			options: models.ConvertOptions{
+				Language: "zh",
+			},
+		},
+		{
+			name:           "Derived Annotations API",
+			inputFile:      "../../test/annotations.json",
+			expectedOutput: "../../test/expected-annotations-mcp.yaml",
+			serverName:     "annotations-api",
+			options: models.ConvertOptions{
+				DeriveAnnotations: true,
+			},
+		},
+		{
+			name:           "Prompts API",
+			inputFile:      "../../test/prompts.json",
+			expectedOutput: "../../test/expected-prompts-mcp.yaml",
+			serverName:     "prompts-api",
+			options: models.ConvertOptions{
+				EmitPrompts: true,
+			},
+		},
+		{
+			name:           "Resources API",
+			inputFile:      "../../test/resources.json",
+			expectedOutput: "../../test/expected-resources-mcp.yaml",
+			serverName:     "resources-api",
+			options: models.ConvertOptions{
+				GetAsResources: true,
+			},
+		},
+		{
+			name:           "Cache Policy API",
+			inputFile:      "../../test/cache.json",
+			expectedOutput: "../../test/expected-cache-mcp.yaml",
+			serverName:     "cache-api",
+		},
+		{
+			name:           "Rate Limit API",
+			inputFile:      "../../test/ratelimit.json",
+			expectedOutput: "../../test/expected-ratelimit-mcp.yaml",
+			serverName:     "ratelimit-api",
+			options:        models.ConvertOptions{RateLimitInDescription: true},
+		},
+		{
+			name:           "XML Body API",
+			inputFile:      "../../test/xml-body.json",
+			expectedOutput: "../../test/expected-xml-body-mcp.yaml",
+			serverName:     "xml-body-api",
+		},
+		{
+			name:           "Naming Heuristics API",
+			inputFile:      "../../test/naming-heuristics.json",
+			expectedOutput: "../../test/expected-naming-heuristics-mcp.yaml",
+			serverName:     "naming-heuristics-api",
+			options: models.ConvertOptions{
+				InferFormats: true,
+			},
+		},
+		{
+			name:           "Options From Spec API",
+			inputFile:      "../../test/mcp-options.json",
+			expectedOutput: "../../test/expected-mcp-options-mcp.yaml",
+			serverName:     "mcp-options-api",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -88,10 +227,10 @@ func TestEndToEndConversion(t *testing.T) {
 			assert.NoError(t, err)
 
 			// Create a new converter
-			c := NewConverter(p, models.ConvertOptions{
-				ServerName:   tc.serverName,
-				TemplatePath: tc.templatePath,
-			})
+			options := tc.options
+			options.ServerName = tc.serverName
+			options.TemplatePath = tc.templatePath
+			c := NewConverter(p, options)
 
 			// Convert the OpenAPI specification to an MCP configuration
 			config, err := c.Convert()
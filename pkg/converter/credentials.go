@@ -0,0 +1,55 @@
+package converter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+)
+
+// Kinds of credential references
+const (
+	CredentialFromEnv    = "env"
+	CredentialFromFile   = "file"
+	CredentialFromSecret = "secret"
+)
+
+// ParseCredentialSource parses a credential reference of the form kind:value,
+// e.g. env:PETSTORE_API_KEY, file:/run/secrets/petstore or secret:higress-system/petstore/api-key
+func ParseCredentialSource(ref string) (models.CredentialSource, error) {
+	kind, value, ok := strings.Cut(ref, ":")
+	if !ok || value == "" {
+		return models.CredentialSource{}, fmt.Errorf("invalid credential reference %q, expected env:NAME, file:PATH or secret:REF", ref)
+	}
+	switch kind {
+	case CredentialFromEnv:
+		return models.CredentialSource{Env: value}, nil
+	case CredentialFromFile:
+		return models.CredentialSource{File: value}, nil
+	case CredentialFromSecret:
+		return models.CredentialSource{Secret: value}, nil
+	}
+	return models.CredentialSource{}, fmt.Errorf("unknown credential reference kind %q, expected %s, %s or %s", kind, CredentialFromEnv, CredentialFromFile, CredentialFromSecret)
+}
+
+// applyCredentialSources sets the credential references of the CredentialFrom option on the security
+// schemes, dropping their default credential so that no literal credential ends up in the configuration
+func (c *Converter) applyCredentialSources(config *models.MCPConfig) error {
+	ids := make([]string, 0, len(c.options.CredentialFrom))
+	for id := range c.options.CredentialFrom {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		index := findSecurityScheme(config.Server.SecuritySchemes, id)
+		if index < 0 {
+			return fmt.Errorf("security scheme %q of the credential references is not defined", id)
+		}
+		source := c.options.CredentialFrom[id]
+		config.Server.SecuritySchemes[index].CredentialFrom = &source
+		config.Server.SecuritySchemes[index].DefaultCredential = ""
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCredentialSource(t *testing.T) {
+	tests := []struct {
+		ref      string
+		expected models.CredentialSource
+		wantErr  string
+	}{
+		{ref: "env:PETSTORE_API_KEY", expected: models.CredentialSource{Env: "PETSTORE_API_KEY"}},
+		{ref: "file:/run/secrets/petstore", expected: models.CredentialSource{File: "/run/secrets/petstore"}},
+		{ref: "secret:higress-system/petstore/api-key", expected: models.CredentialSource{Secret: "higress-system/petstore/api-key"}},
+		{ref: "PETSTORE_API_KEY", wantErr: `invalid credential reference "PETSTORE_API_KEY", expected env:NAME, file:PATH or secret:REF`},
+		{ref: "vault:petstore", wantErr: `unknown credential reference kind "vault", expected env, file or secret`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.ref, func(t *testing.T) {
+			source, err := ParseCredentialSource(tc.ref)
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, source)
+		})
+	}
+}
+
+func TestCredentialFrom(t *testing.T) {
+	spec := `{
+  "openapi": "3.0.0",
+  "info": {"title": "Credentials", "version": "1.0.0"},
+  "servers": [{"url": "https://api.example.com"}],
+  "paths": {},
+  "components": {
+    "securitySchemes": {
+      "ApiKeyAuth": {"type": "apiKey", "in": "header", "name": "X-API-Key", "x-mcp-default-credential": "sk-live-123"},
+      "BearerAuth": {"type": "http", "scheme": "bearer"}
+    }
+  }
+}`
+
+	convert := func(credentialFrom map[string]models.CredentialSource) (*models.MCPConfig, error) {
+		p := parser.NewParser()
+		p.SetValidation(false)
+		assert.NoError(t, p.Parse([]byte(spec)))
+		return NewConverter(p, models.ConvertOptions{CredentialFrom: credentialFrom}).Convert()
+	}
+
+	config, err := convert(map[string]models.CredentialSource{"ApiKeyAuth": {Env: "PETSTORE_API_KEY"}})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []models.SecurityScheme{
+			{ID: "ApiKeyAuth", Type: "apiKey", In: "header", Name: "X-API-Key", CredentialFrom: &models.CredentialSource{Env: "PETSTORE_API_KEY"}},
+			{ID: "BearerAuth", Type: "http", Scheme: "bearer"},
+		}, config.Server.SecuritySchemes)
+	}
+
+	_, err = convert(map[string]models.CredentialSource{"OAuth": {File: "/run/secrets/token"}})
+	assert.EqualError(t, err, `security scheme "OAuth" of the credential references is not defined`)
+}
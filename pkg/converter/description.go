@@ -0,0 +1,155 @@
+package converter
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// ellipsis marks descriptions that were cut in the middle of a sentence
+const ellipsis = "…"
+
+// descriptionData is the data available to description templates
+type descriptionData struct {
+	Method      string
+	Path        string
+	OperationID string
+	Summary     string
+	Description string
+	Tags        descriptionTags
+}
+
+// descriptionTags prints as a comma-separated list in templates
+type descriptionTags []string
+
+func (t descriptionTags) String() string {
+	return strings.Join(t, ", ")
+}
+
+// parseDescriptionTemplate parses the description template, checking the fields it uses
+func (c *Converter) parseDescriptionTemplate() error {
+	c.descriptionTemplate = nil
+	if c.options.DescriptionTemplate == "" {
+		return nil
+	}
+	tmpl, err := template.New("description").Parse(c.options.DescriptionTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse description template: %w", err)
+	}
+	if err := tmpl.Execute(&strings.Builder{}, descriptionData{}); err != nil {
+		return fmt.Errorf("invalid description template: %w", err)
+	}
+	c.descriptionTemplate = tmpl
+	return nil
+}
+
+// toolDescription builds the description of the tool generated for an operation
+func (c *Converter) toolDescription(operation *openapi3.Operation) string {
+	return truncateDescription(c.operationDescription(operation), c.options.MaxDescriptionLength)
+}
+
+// templateDescription renders the description template for an operation, which is then truncated.
+// Without a template it returns the tool description.
+func (c *Converter) templateDescription(path, method string, operation *openapi3.Operation) (string, error) {
+	if c.descriptionTemplate == nil {
+		return c.toolDescription(operation), nil
+	}
+	data := descriptionData{
+		Method:      strings.ToUpper(method),
+		Path:        path,
+		OperationID: c.parser.GetOperationID(path, method, operation),
+		Summary:     c.formatDescription(c.localize(operation.Extensions, "summary", operation.Summary)),
+		Description: c.operationDescription(operation),
+		Tags:        operation.Tags,
+	}
+	var description strings.Builder
+	if err := c.descriptionTemplate.Execute(&description, data); err != nil {
+		return "", fmt.Errorf("failed to render description template: %w", err)
+	}
+	return truncateDescription(strings.TrimSpace(description.String()), c.options.MaxDescriptionLength), nil
+}
+
+// operationDescription returns the description of an operation, or its summary if it has none
+func (c *Converter) operationDescription(operation *openapi3.Operation) string {
+	summary := c.localize(operation.Extensions, "summary", operation.Summary)
+	description := c.localize(operation.Extensions, "description", operation.Description)
+	if description == "" || (c.options.DescriptionSummary && summary != "") {
+		description = summary
+	}
+	description = c.formatDescription(description)
+	if c.options.DescriptionSummary {
+		description = firstSentence(description)
+	}
+	return description
+}
+
+// argDescription builds the description of an argument from its description and extensions
+func (c *Converter) argDescription(extensions map[string]any, description string) string {
+	description = c.formatDescription(c.localize(extensions, "description", description))
+	if c.options.DescriptionSummary {
+		description = firstSentence(description)
+	}
+	return truncateDescription(description, c.options.MaxArgDescriptionLength)
+}
+
+// truncateDescription shortens a description to at most maxLength characters.
+// It prefers cutting at the end of a sentence, then at a word boundary followed by an ellipsis.
+// A maxLength of zero or less disables truncation.
+func truncateDescription(description string, maxLength int) string {
+	runes := []rune(description)
+	if maxLength <= 0 || len(runes) <= maxLength {
+		return description
+	}
+
+	// Cut after the last complete sentence if that keeps at least half of the budget
+	window := runes[:maxLength]
+	for i := len(window) - 1; i >= maxLength/2; i-- {
+		if isSentenceEnd(runes, i) {
+			return strings.TrimSpace(string(runes[:i+1]))
+		}
+	}
+
+	// Otherwise cut at the last word boundary and mark the truncation
+	window = runes[:maxLength-1]
+	cut := len(window)
+	for i := len(window) - 1; i >= maxLength/2; i-- {
+		if unicode.IsSpace(window[i]) {
+			cut = i
+			break
+		}
+	}
+	return strings.TrimRightFunc(string(runes[:cut]), func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	}) + ellipsis
+}
+
+// firstSentence returns the first sentence of the first paragraph of a description
+func firstSentence(description string) string {
+	description = strings.TrimSpace(description)
+	if i := strings.Index(description, "\n\n"); i >= 0 {
+		description = description[:i]
+	}
+	runes := []rune(description)
+	for i := range runes {
+		if isSentenceEnd(runes, i) {
+			return strings.TrimSpace(string(runes[:i+1]))
+		}
+	}
+	return description
+}
+
+// isSentenceEnd checks if the rune at index i terminates a sentence
+func isSentenceEnd(runes []rune, i int) bool {
+	switch runes[i] {
+	case '。', '！', '？':
+		return true
+	case '.', '!', '?':
+		// Require whitespace (or the end of the text) after the punctuation so that
+		// abbreviations like "e.g." in the middle of a word or version numbers are not split
+		return i == len(runes)-1 || unicode.IsSpace(runes[i+1])
+	}
+	return false
+}
@@ -0,0 +1,139 @@
+package converter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncateDescription(t *testing.T) {
+	testCases := []struct {
+		name        string
+		description string
+		maxLength   int
+		expected    string
+	}{
+		{
+			name:        "Unlimited",
+			description: "Returns all pets from the system that the user has access to.",
+			maxLength:   0,
+			expected:    "Returns all pets from the system that the user has access to.",
+		},
+		{
+			name:        "Within budget",
+			description: "List all pets.",
+			maxLength:   20,
+			expected:    "List all pets.",
+		},
+		{
+			name:        "Sentence boundary",
+			description: "Returns all pets. The result is paginated using the nextPage cursor.",
+			maxLength:   30,
+			expected:    "Returns all pets.",
+		},
+		{
+			name:        "Word boundary",
+			description: "Returns all pets from the system that the user has access to",
+			maxLength:   30,
+			expected:    "Returns all pets from the…",
+		},
+		{
+			name:        "CJK sentence boundary",
+			description: "搜索用户。支持按关键字和创建时间范围过滤，结果按创建时间倒序排列。",
+			maxLength:   8,
+			expected:    "搜索用户。",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := truncateDescription(tc.description, tc.maxLength)
+			assert.Equal(t, tc.expected, actual)
+			if tc.maxLength > 0 {
+				assert.LessOrEqual(t, len([]rune(actual)), tc.maxLength)
+			}
+		})
+	}
+}
+
+func TestFirstSentence(t *testing.T) {
+	assert.Equal(t, "Returns all pets.", firstSentence("Returns all pets. Supports v1.2 filters."))
+	assert.Equal(t, "Uses v1.2 filters.", firstSentence("Uses v1.2 filters.\n\nMore details."))
+	assert.Equal(t, "No punctuation", firstSentence("No punctuation"))
+}
+
+func TestMapResponseDescription(t *testing.T) {
+	p := parser.NewParser()
+	assert.NoError(t, p.ParseFile("../../test/additional-properties.json"))
+	c := NewConverter(p, models.ConvertOptions{})
+
+	response := p.GetPaths()["/projects"].Get.Responses["200"].Value
+	var description strings.Builder
+	c.writeMapValues(&description, response.Content["application/json"].Schema.Value, "", 0, 10)
+
+	assert.Equal(t, "- **{key}**: Value of each map entry (Type: object)\n"+
+		"  - **{key}.labels**: Labels of the project (Type: object)\n"+
+		"    - **{key}.labels.{key}**: Value of each map entry (Type: string)\n"+
+		"  - **{key}.name**: Project name (Type: string)\n", description.String())
+}
+
+func TestDescriptionTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  models.ConvertOptions
+		expected map[string]string
+	}{
+		{
+			name:    "method path and tags",
+			options: models.ConvertOptions{DescriptionTemplate: "[{{.Method}} {{.Path}}] {{.Summary}} (tags: {{.Tags}})"},
+			expected: map[string]string{
+				"listPets":    "[GET /pets] List all pets (tags: pets)",
+				"createPets":  "[POST /pets] Create a pet (tags: pets)",
+				"showPetById": "[GET /pets/{petId}] Info for a specific pet (tags: pets)",
+			},
+		},
+		{
+			name:    "operation ID and truncation",
+			options: models.ConvertOptions{DescriptionTemplate: "{{.OperationID}}: {{.Description}}", MaxDescriptionLength: 20},
+			expected: map[string]string{
+				"listPets":    "listPets: List all…",
+				"createPets":  "createPets: Create…",
+				"showPetById": "showPetById: Info…",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := parser.NewParser()
+			if !assert.NoError(t, p.ParseFile("../../test/petstore.json")) {
+				return
+			}
+			config, err := NewConverter(p, tt.options).Convert()
+			if !assert.NoError(t, err) {
+				return
+			}
+			descriptions := make(map[string]string)
+			for _, tool := range config.Tools {
+				descriptions[tool.Name] = tool.Description
+			}
+			assert.Equal(t, tt.expected, descriptions)
+		})
+	}
+}
+
+func TestDescriptionTemplateErrors(t *testing.T) {
+	p := parser.NewParser()
+	if !assert.NoError(t, p.ParseFile("../../test/petstore.json")) {
+		return
+	}
+
+	_, err := NewConverter(p, models.ConvertOptions{DescriptionTemplate: "{{.Method"}).Convert()
+	assert.ErrorContains(t, err, "failed to parse description template")
+
+	_, err = NewConverter(p, models.ConvertOptions{DescriptionTemplate: "{{.Verb}}"}).Convert()
+	assert.ErrorContains(t, err, "invalid description template")
+}
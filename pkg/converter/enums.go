@@ -0,0 +1,76 @@
+package converter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+)
+
+const (
+	// enumDescriptionsExtension gives the meaning of each enum value, as a list in the order of
+	// the enum or a map keyed by value
+	enumDescriptionsExtension = "x-enum-descriptions"
+	// enumVarNamesExtension gives the name of each enum value, in the order of the enum
+	enumVarNamesExtension = "x-enum-varnames"
+)
+
+// describeEnum appends the meaning of the enum values of a schema to the description of an arg,
+// e.g. `Values: 1 (PENDING): awaiting payment; 2 (SHIPPED): on its way`
+func describeEnum(arg *models.Arg, schema *openapi3.Schema) {
+	if len(schema.Enum) == 0 {
+		return
+	}
+	descriptions := enumExtension(schema.Extensions[enumDescriptionsExtension], schema.Enum)
+	names := enumExtension(schema.Extensions[enumVarNamesExtension], schema.Enum)
+
+	values := make([]string, 0, len(schema.Enum))
+	for i, value := range schema.Enum {
+		entry := formatValue(value)
+		if names[i] != "" && names[i] != entry {
+			entry += " (" + names[i] + ")"
+		}
+		if descriptions[i] != "" {
+			entry += ": " + descriptions[i]
+		}
+		if entry != formatValue(value) {
+			values = append(values, entry)
+		}
+	}
+	if len(values) == 0 {
+		return
+	}
+	arg.Description = appendSentence(arg.Description, "Values: "+strings.Join(values, "; "))
+}
+
+// enumExtension returns the strings an enum extension gives to each enum value, in the order of the enum
+func enumExtension(raw any, enum []any) []string {
+	values := make([]string, len(enum))
+	switch extension := raw.(type) {
+	case []any:
+		for i := range min(len(extension), len(enum)) {
+			if value, ok := extension[i].(string); ok {
+				values[i] = strings.TrimSpace(value)
+			}
+		}
+	case map[string]any:
+		for i, value := range enum {
+			if description, ok := extension[formatValue(value)].(string); ok {
+				values[i] = strings.TrimSpace(description)
+			}
+		}
+	}
+	return values
+}
+
+// formatValue formats a scalar value of a schema as written in the spec. Numbers decode as
+// float64, which fmt prints in exponent form, e.g. 1e+06 for 1000000.
+func formatValue(value any) string {
+	if number, ok := value.(float64); ok {
+		return strconv.FormatFloat(number, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,79 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescribeEnum(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		schema      *openapi3.Schema
+		expected    string
+	}{
+		{
+			name:        "descriptions",
+			description: "Order status",
+			schema: &openapi3.Schema{
+				Enum:       []any{1.0, 2.0, 3.0},
+				Extensions: map[string]any{"x-enum-descriptions": []any{"Awaiting payment", "On its way", "Delivered"}},
+			},
+			expected: "Order status. Values: 1: Awaiting payment; 2: On its way; 3: Delivered",
+		},
+		{
+			name:        "names and descriptions",
+			description: "Order status.",
+			schema: &openapi3.Schema{
+				Enum: []any{1.0, 2.0},
+				Extensions: map[string]any{
+					"x-enum-varnames":     []any{"PENDING", "SHIPPED"},
+					"x-enum-descriptions": []any{"Awaiting payment"},
+				},
+			},
+			expected: "Order status. Values: 1 (PENDING): Awaiting payment; 2 (SHIPPED)",
+		},
+		{
+			name: "descriptions keyed by value",
+			schema: &openapi3.Schema{
+				Enum:       []any{"asc", "desc"},
+				Extensions: map[string]any{"x-enum-descriptions": map[string]any{"desc": "Newest first"}},
+			},
+			expected: "Values: desc: Newest first",
+		},
+		{
+			name: "large numbers keyed by value",
+			schema: &openapi3.Schema{
+				Enum:       []any{1000000.0, 2.5},
+				Extensions: map[string]any{"x-enum-descriptions": map[string]any{"1000000": "One million", "2.5": "Two and a half"}},
+			},
+			expected: "Values: 1000000: One million; 2.5: Two and a half",
+		},
+		{
+			name:        "names matching the values",
+			description: "Sort order",
+			schema: &openapi3.Schema{
+				Enum:       []any{"asc", "desc"},
+				Extensions: map[string]any{"x-enum-varnames": []any{"asc", "desc"}},
+			},
+			expected: "Sort order",
+		},
+		{
+			name:        "no extensions",
+			description: "Sort order",
+			schema:      &openapi3.Schema{Enum: []any{"asc", "desc"}},
+			expected:    "Sort order",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			arg := models.Arg{Description: tc.description}
+			describeEnum(&arg, tc.schema)
+			assert.Equal(t, tc.expected, arg.Description)
+		})
+	}
+}
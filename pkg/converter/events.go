@@ -0,0 +1,154 @@
+package converter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+)
+
+// Event kinds
+const (
+	EventKindCallback = "callback"
+	EventKindWebhook  = "webhook"
+)
+
+// callbackEvents describes the callbacks an operation registers. Events are named after the tool
+// and the callback, with the method appended when a callback has several operations.
+func (c *Converter) callbackEvents(tool *models.Tool, operation *openapi3.Operation) ([]models.Event, error) {
+	names := make([]string, 0, len(operation.Callbacks))
+	for name := range operation.Callbacks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var events []models.Event
+	for _, name := range names {
+		callbackRef := operation.Callbacks[name]
+		if callbackRef == nil || callbackRef.Value == nil {
+			continue
+		}
+		newEvents, err := c.pathItemEvents(tool.Name+"_"+name, EventKindCallback, *callbackRef.Value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert callback %s: %w", name, err)
+		}
+		for i := range newEvents {
+			newEvents[i].Tool = tool.Name
+		}
+		events = append(events, newEvents...)
+	}
+	return events, nil
+}
+
+// webhookEvents describes the webhooks of an OpenAPI 3.1 document. Events are named after
+// the operation ID or the webhook.
+func (c *Converter) webhookEvents() ([]models.Event, error) {
+	webhooks, err := c.parser.GetWebhooks()
+	if err != nil {
+		return nil, err
+	}
+	var events []models.Event
+	for _, name := range sortedPathNames(webhooks) {
+		pathItem := webhooks[name]
+		// The operation ID is a better name than the webhook key, which is often a description
+		if operations := getOperations(pathItem); len(operations) == 1 {
+			for _, operation := range operations {
+				if operation.OperationID != "" {
+					name = operation.OperationID
+				}
+			}
+		}
+		newEvents, err := c.pathItemEvents(name, EventKindWebhook, map[string]*openapi3.PathItem{"": pathItem})
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert webhook %s: %w", name, err)
+		}
+		events = append(events, newEvents...)
+	}
+	return events, nil
+}
+
+// pathItemEvents converts the operations of the path items of a callback, keyed by URL expression, to events
+func (c *Converter) pathItemEvents(name, kind string, pathItems map[string]*openapi3.PathItem) ([]models.Event, error) {
+	type eventOperation struct {
+		url, method string
+		operation   *openapi3.Operation
+	}
+	var operations []eventOperation
+	for _, url := range sortedPathNames(pathItems) {
+		if pathItems[url] == nil {
+			continue
+		}
+		itemOperations := getOperations(pathItems[url])
+		methods := make([]string, 0, len(itemOperations))
+		for method := range itemOperations {
+			methods = append(methods, method)
+		}
+		sort.Strings(methods)
+		for _, method := range methods {
+			operations = append(operations, eventOperation{url: url, method: method, operation: itemOperations[method]})
+		}
+	}
+
+	events := make([]models.Event, 0, len(operations))
+	for _, op := range operations {
+		payload, err := c.eventPayload(op.operation)
+		if err != nil {
+			return nil, err
+		}
+		event := models.Event{
+			Name:        name,
+			Kind:        kind,
+			Description: c.toolDescription(op.operation),
+			URL:         op.url,
+			Method:      strings.ToUpper(op.method),
+			Payload:     payload,
+		}
+		if len(operations) > 1 {
+			event.Name += "_" + strings.ToLower(op.method)
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// eventPayload converts the parameters and request body of the request sent by the API, like the args of a tool
+func (c *Converter) eventPayload(operation *openapi3.Operation) ([]models.Arg, error) {
+	payload, err := c.convertParameters(operation.Parameters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert parameters: %w", err)
+	}
+	bodyArgs, err := c.convertRequestBody(operation.RequestBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert request body: %w", err)
+	}
+	sort.Slice(bodyArgs, func(i, j int) bool {
+		return bodyArgs[i].Name < bodyArgs[j].Name
+	})
+	payload = append(payload, bodyArgs...)
+	if !c.options.KeepArgOrder {
+		sort.SliceStable(payload, func(i, j int) bool {
+			return payload[i].Name < payload[j].Name
+		})
+	}
+	return payload, nil
+}
+
+// sortedPathNames returns the keys of path items in order
+func sortedPathNames(pathItems map[string]*openapi3.PathItem) []string {
+	names := make([]string, 0, len(pathItems))
+	for name := range pathItems {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sortEvents sorts events by name
+func sortEvents(events []models.Event) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Name < events[j].Name
+	})
+}
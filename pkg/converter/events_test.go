@@ -0,0 +1,54 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvents(t *testing.T) {
+	spec := `{
+  "openapi": "3.1.0",
+  "info": {"title": "Events", "version": "1.0.0"},
+  "servers": [{"url": "https://api.example.com"}],
+  "paths": {
+    "/hooks": {
+      "post": {
+        "operationId": "registerHook",
+        "callbacks": {
+          "onChange": {
+            "{$request.query.url}": {
+              "put": {"summary": "Resource replaced", "responses": {"200": {"description": "OK"}}},
+              "delete": {"summary": "Resource deleted", "responses": {"200": {"description": "OK"}}}
+            }
+          }
+        },
+        "responses": {"201": {"description": "Created"}}
+      }
+    }
+  },
+  "webhooks": {
+    "ping": {
+      "post": {"summary": "Connectivity check", "responses": {"200": {"description": "OK"}}}
+    }
+  }
+}`
+
+	convert := func(options models.ConvertOptions) *models.MCPConfig {
+		p := parser.NewParser()
+		p.SetValidation(false)
+		assert.NoError(t, p.Parse([]byte(spec)))
+		config, err := NewConverter(p, options).Convert()
+		assert.NoError(t, err)
+		return config
+	}
+
+	assert.Empty(t, convert(models.ConvertOptions{}).Events)
+	assert.Equal(t, []models.Event{
+		{Name: "ping", Kind: EventKindWebhook, Description: "Connectivity check", Method: "POST", Payload: []models.Arg{}},
+		{Name: "registerHook_onChange_delete", Kind: EventKindCallback, Description: "Resource deleted", Tool: "registerHook", URL: "{$request.query.url}", Method: "DELETE", Payload: []models.Arg{}},
+		{Name: "registerHook_onChange_put", Kind: EventKindCallback, Description: "Resource replaced", Tool: "registerHook", URL: "{$request.query.url}", Method: "PUT", Payload: []models.Arg{}},
+	}, convert(models.ConvertOptions{EmitEvents: true}).Events)
+}
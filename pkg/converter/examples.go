@@ -0,0 +1,116 @@
+package converter
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+)
+
+// maxDescribedExamples limits the number of examples appended to a description
+const maxDescribedExamples = 3
+
+// appendExample adds an example to a list, skipping nil values and duplicates
+func appendExample(examples []any, example any) []any {
+	if example == nil {
+		return examples
+	}
+	for _, existing := range examples {
+		if reflect.DeepEqual(existing, example) {
+			return examples
+		}
+	}
+	return append(examples, example)
+}
+
+// namedExamples returns the values of named examples, sorted by name
+func namedExamples(examples openapi3.Examples) []any {
+	names := make([]string, 0, len(examples))
+	for name := range examples {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	values := make([]any, 0, len(names))
+	for _, name := range names {
+		if ref := examples[name]; ref != nil && ref.Value != nil {
+			values = append(values, ref.Value.Value)
+		}
+	}
+	return values
+}
+
+// parameterExamples collects the example, named examples and schema example of a parameter
+func parameterExamples(param *openapi3.Parameter) []any {
+	examples := appendExample(nil, param.Example)
+	for _, example := range namedExamples(param.Examples) {
+		examples = appendExample(examples, example)
+	}
+	if param.Schema != nil && param.Schema.Value != nil {
+		examples = appendExample(examples, param.Schema.Value.Example)
+	}
+	return examples
+}
+
+// bodyExamples returns the values of a property in the example and named examples of a request body
+func bodyExamples(mediaType *openapi3.MediaType, propName string) []any {
+	var examples []any
+	for _, example := range append([]any{mediaType.Example}, namedExamples(mediaType.Examples)...) {
+		if body, ok := example.(map[string]any); ok {
+			examples = appendExample(examples, body[propName])
+		}
+	}
+	return examples
+}
+
+// describeExamples appends the examples of args and their nested properties to their descriptions
+func describeExamples(args []models.Arg) {
+	for i := range args {
+		describeArgExamples(&args[i])
+	}
+}
+
+// describeArgExamples appends the examples of an arg to its description, e.g. `Examples: "2024-01-31", "2024-02-29"`
+func describeArgExamples(arg *models.Arg) {
+	for name, property := range arg.Properties {
+		describeArgExamples(&property)
+		arg.Properties[name] = property
+	}
+	if arg.Items != nil {
+		describeArgExamples(arg.Items)
+	}
+	if len(arg.Examples) == 0 {
+		return
+	}
+
+	values := make([]string, 0, maxDescribedExamples)
+	for _, example := range arg.Examples[:min(len(arg.Examples), maxDescribedExamples)] {
+		encoded, err := json.Marshal(example)
+		if err != nil {
+			continue
+		}
+		values = append(values, string(encoded))
+	}
+	label := "Example: "
+	if len(values) > 1 {
+		label = "Examples: "
+	}
+	arg.Description = appendSentence(arg.Description, label+strings.Join(values, ", "))
+}
+
+// appendSentence appends a sentence to a description, ending the description with a period if needed
+func appendSentence(description, sentence string) string {
+	description = strings.TrimSpace(description)
+	switch {
+	case description == "":
+		return sentence
+	case strings.HasSuffix(description, "."), strings.HasSuffix(description, "!"), strings.HasSuffix(description, "?"):
+		return description + " " + sentence
+	default:
+		return description + ". " + sentence
+	}
+}
@@ -0,0 +1,55 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParameterExamples(t *testing.T) {
+	schema := openapi3.NewStringSchema()
+	schema.Example = "2024-01-31"
+	param := openapi3.NewQueryParameter("since").WithSchema(schema)
+	param.Example = "2024-02-29"
+	param.Examples = openapi3.Examples{
+		"leap":  {Value: openapi3.NewExample("2024-02-29")},
+		"first": {Value: openapi3.NewExample("2024-01-01")},
+	}
+
+	// The parameter example comes first, then named examples by name, without duplicates
+	assert.Equal(t, []any{"2024-02-29", "2024-01-01", "2024-01-31"}, parameterExamples(param))
+}
+
+func TestBodyExamples(t *testing.T) {
+	mediaType := openapi3.NewMediaType()
+	mediaType.Example = map[string]any{"name": "Alice", "age": float64(30)}
+	mediaType.Examples = openapi3.Examples{
+		"bob":   {Value: openapi3.NewExample(map[string]any{"name": "Bob"})},
+		"alice": {Value: openapi3.NewExample(map[string]any{"name": "Alice"})},
+	}
+
+	assert.Equal(t, []any{"Alice", "Bob"}, bodyExamples(mediaType, "name"))
+	assert.Equal(t, []any{float64(30)}, bodyExamples(mediaType, "age"))
+	assert.Nil(t, bodyExamples(mediaType, "email"))
+}
+
+func TestDescribeExamples(t *testing.T) {
+	args := []models.Arg{
+		{Name: "since", Description: "Start date", Examples: []any{"2024-01-31", "2024-02-29"}},
+		{Name: "limit", Description: "Page size.", Examples: []any{float64(20)}},
+		{Name: "tag", Examples: []any{"a", "b", "c", "d"}},
+		{Name: "filter", Properties: map[string]models.Arg{
+			"status": {Name: "status", Description: "Status", Examples: []any{"open"}},
+		}},
+		{Name: "sort", Description: "Sort order"},
+	}
+	describeExamples(args)
+
+	assert.Equal(t, `Start date. Examples: "2024-01-31", "2024-02-29"`, args[0].Description)
+	assert.Equal(t, `Page size. Example: 20`, args[1].Description)
+	assert.Equal(t, `Examples: "a", "b", "c"`, args[2].Description)
+	assert.Equal(t, `Status. Example: "open"`, args[3].Properties["status"].Description)
+	assert.Equal(t, "Sort order", args[4].Description)
+}
@@ -0,0 +1,67 @@
+package converter
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+)
+
+// LowValueMethods are the HTTP methods whose operations are skipped unless listed in Filter.IncludeMethods,
+// as they rarely make useful tools
+var LowValueMethods = []string{"head", "options", "trace"}
+
+// matchFilter checks if an operation is selected by a filter
+func matchFilter(filter models.Filter, operationPath, method, operationID string, operation *openapi3.Operation) bool {
+	return filterReason(filter, operationPath, method, operationID, operation) == ""
+}
+
+// filterReason explains why a filter skips an operation, or returns an empty string if the operation is selected.
+// Operations with a low-value method are skipped unless their method or operation ID is included.
+func filterReason(filter models.Filter, operationPath, method, operationID string, operation *openapi3.Operation) string {
+	if slices.Contains(LowValueMethods, strings.ToLower(method)) &&
+		!slices.ContainsFunc(filter.IncludeMethods, func(included string) bool { return strings.EqualFold(included, method) }) &&
+		!slices.Contains(filter.IncludeOperations, operationID) {
+		return fmt.Sprintf("%s operations are skipped unless included by method", strings.ToUpper(method))
+	}
+	hasIncludes := len(filter.IncludeTags) > 0 || len(filter.IncludePaths) > 0 || len(filter.IncludeOperations) > 0
+	if hasIncludes &&
+		!matchTags(filter.IncludeTags, operation.Tags) &&
+		!matchPaths(filter.IncludePaths, operationPath) &&
+		!slices.Contains(filter.IncludeOperations, operationID) {
+		return "not matched by any include rule"
+	}
+	switch {
+	case matchTags(filter.ExcludeTags, operation.Tags):
+		return "excluded by tag"
+	case matchPaths(filter.ExcludePaths, operationPath):
+		return "excluded by path pattern"
+	case slices.Contains(filter.ExcludeOperations, operationID):
+		return "excluded by operation ID"
+	}
+	return ""
+}
+
+// matchTags checks if any of the operation tags is listed
+func matchTags(tags, operationTags []string) bool {
+	for _, tag := range operationTags {
+		if slices.Contains(tags, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPaths checks if a path matches any of the glob patterns
+func matchPaths(patterns []string, operationPath string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, operationPath); matched {
+			return true
+		}
+	}
+	return false
+}
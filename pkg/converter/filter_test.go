@@ -0,0 +1,84 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchFilter(t *testing.T) {
+	operation := &openapi3.Operation{OperationID: "getUser", Tags: []string{"users", "public"}}
+
+	tests := []struct {
+		name     string
+		filter   models.Filter
+		expected bool
+	}{
+		{name: "Empty filter", filter: models.Filter{}, expected: true},
+		{name: "Included tag", filter: models.Filter{IncludeTags: []string{"users"}}, expected: true},
+		{name: "Other included tag", filter: models.Filter{IncludeTags: []string{"orders"}}, expected: false},
+		{name: "Included path pattern", filter: models.Filter{IncludePaths: []string{"/users/*"}}, expected: true},
+		{name: "Path pattern matches a single segment", filter: models.Filter{IncludePaths: []string{"/*"}}, expected: false},
+		{name: "Included operation", filter: models.Filter{IncludeOperations: []string{"getUser"}}, expected: true},
+		{name: "Any include rule selects", filter: models.Filter{IncludeTags: []string{"orders"}, IncludeOperations: []string{"getUser"}}, expected: true},
+		{name: "Excluded tag", filter: models.Filter{ExcludeTags: []string{"public"}}, expected: false},
+		{name: "Exclude wins over include", filter: models.Filter{IncludeTags: []string{"users"}, ExcludePaths: []string{"/users/{id}"}}, expected: false},
+		{name: "Excluded operation", filter: models.Filter{ExcludeOperations: []string{"getUser"}}, expected: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, matchFilter(tc.filter, "/users/{id}", "get", operation.OperationID, operation))
+		})
+	}
+}
+
+func TestFilterReason(t *testing.T) {
+	operation := &openapi3.Operation{OperationID: "getUser", Tags: []string{"users"}}
+
+	tests := []struct {
+		name     string
+		filter   models.Filter
+		expected string
+	}{
+		{name: "Selected", filter: models.Filter{IncludeTags: []string{"users"}}, expected: ""},
+		{name: "Not included", filter: models.Filter{IncludePaths: []string{"/orders/*"}}, expected: "not matched by any include rule"},
+		{name: "Excluded tag", filter: models.Filter{ExcludeTags: []string{"users"}}, expected: "excluded by tag"},
+		{name: "Excluded path", filter: models.Filter{ExcludePaths: []string{"/users/*"}}, expected: "excluded by path pattern"},
+		{name: "Excluded operation", filter: models.Filter{ExcludeOperations: []string{"getUser"}}, expected: "excluded by operation ID"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, filterReason(tc.filter, "/users/{id}", "get", operation.OperationID, operation))
+		})
+	}
+}
+
+func TestFilterReasonMethods(t *testing.T) {
+	operation := &openapi3.Operation{OperationID: "headUser", Tags: []string{"users"}}
+
+	tests := []struct {
+		name     string
+		method   string
+		filter   models.Filter
+		expected string
+	}{
+		{name: "HEAD skipped by default", method: "head", expected: "HEAD operations are skipped unless included by method"},
+		{name: "OPTIONS skipped by default", method: "options", expected: "OPTIONS operations are skipped unless included by method"},
+		{name: "TRACE skipped by default", method: "trace", expected: "TRACE operations are skipped unless included by method"},
+		{name: "Included method", method: "head", filter: models.Filter{IncludeMethods: []string{"HEAD"}}, expected: ""},
+		{name: "Other included method", method: "head", filter: models.Filter{IncludeMethods: []string{"options"}}, expected: "HEAD operations are skipped unless included by method"},
+		{name: "Included operation", method: "head", filter: models.Filter{IncludeOperations: []string{"headUser"}}, expected: ""},
+		{name: "Included method still excluded", method: "head", filter: models.Filter{IncludeMethods: []string{"head"}, ExcludeTags: []string{"users"}}, expected: "excluded by tag"},
+		{name: "Other methods", method: "patch", expected: ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, filterReason(tc.filter, "/users/{id}", tc.method, operation.OperationID, operation))
+		})
+	}
+}
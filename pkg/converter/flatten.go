@@ -0,0 +1,100 @@
+package converter
+
+import (
+	"strings"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+)
+
+// bodyPathPrefix starts the position of args flattened from nested body objects, e.g. "body.user.address.city"
+const bodyPathPrefix = "body."
+
+// flattenBodyArgs replaces object body args by args for their properties, down to depth levels of nesting.
+// Flattened args are named after their path joined with underscores and positioned at the dot-path of the
+// property, so the runtime can rebuild the nested payload. A flattened name already used by another arg
+// falls back to the dot-path itself.
+func flattenBodyArgs(args []models.Arg, depth int) []models.Arg {
+	var flattened []models.Arg
+	for _, arg := range args {
+		flattened = append(flattened, flattenArg(arg, arg.Name, "body", true, depth)...)
+	}
+
+	names := make(map[string]int, len(flattened))
+	for _, arg := range flattened {
+		names[arg.Name]++
+	}
+	for i, arg := range flattened {
+		if names[arg.Name] > 1 && strings.HasPrefix(arg.Position, bodyPathPrefix) {
+			flattened[i].Name = strings.TrimPrefix(arg.Position, bodyPathPrefix)
+		}
+	}
+	return flattened
+}
+
+// flattenArg flattens an arg at the given position; required is false if an enclosing object is optional
+func flattenArg(arg models.Arg, name, position string, required bool, depth int) []models.Arg {
+	arg.Name = name
+	arg.Required = arg.Required && required
+	if position != "body" {
+		arg.Position = position
+	}
+	if depth <= 0 || arg.Type != "object" || len(arg.Properties) == 0 {
+		return []models.Arg{arg}
+	}
+
+	var args []models.Arg
+	for _, propName := range sortedKeys(arg.Properties) {
+		property := arg.Properties[propName]
+		for _, example := range arg.Examples {
+			if object, ok := example.(map[string]any); ok {
+				property.Examples = appendExample(property.Examples, object[propName])
+			}
+		}
+		path := position + "." + propName
+		if position == "body" {
+			path = bodyPathPrefix + arg.Name + "." + propName
+		}
+		args = append(args, flattenArg(property, name+"_"+propName, path, arg.Required, depth-1)...)
+	}
+	return args
+}
+
+// flattenExample converts example arguments to the flattened body args of a tool,
+// moving the values of nested body properties to the args flattened from them
+func flattenExample(example map[string]any, args []models.Arg) map[string]any {
+	var flattened map[string]any
+	for _, arg := range args {
+		if !strings.HasPrefix(arg.Position, bodyPathPrefix) {
+			continue
+		}
+		if flattened == nil {
+			flattened = make(map[string]any, len(example))
+			for k, v := range example {
+				flattened[k] = v
+			}
+		}
+		path := strings.Split(strings.TrimPrefix(arg.Position, bodyPathPrefix), ".")
+		delete(flattened, path[0])
+		if value, ok := lookupPath(example, path); ok {
+			flattened[arg.Name] = value
+		}
+	}
+	if flattened == nil {
+		return example
+	}
+	return flattened
+}
+
+// lookupPath returns the value at a path of nested objects
+func lookupPath(value any, path []string) (any, bool) {
+	for _, key := range path {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if value, ok = object[key]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
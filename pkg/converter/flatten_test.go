@@ -0,0 +1,41 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlattenBodyArgs(t *testing.T) {
+	args := []models.Arg{
+		{Name: "user_name", Type: "string", Position: "body"},
+		{Name: "user", Type: "object", Required: true, Position: "body", Properties: map[string]models.Arg{
+			"name": {Name: "name", Type: "string", Required: true, Position: "body"},
+			"tags": {Name: "tags", Type: "array", Position: "body"},
+		}},
+	}
+
+	assert.Equal(t, []models.Arg{
+		{Name: "user_name", Type: "string", Position: "body"},
+		{Name: "user.name", Type: "string", Required: true, Position: "body.user.name"},
+		{Name: "user_tags", Type: "array", Position: "body.user.tags"},
+	}, flattenBodyArgs(args, 1))
+	assert.Equal(t, args, flattenBodyArgs(args, 0))
+}
+
+func TestFlattenExample(t *testing.T) {
+	args := []models.Arg{
+		{Name: "id", Position: "path"},
+		{Name: "user_name", Position: "body.user.name"},
+		{Name: "user_address_city", Position: "body.user.address.city"},
+	}
+	example := map[string]any{
+		"id":     "u1",
+		"notify": true,
+		"user":   map[string]any{"name": "Alice"},
+	}
+
+	assert.Equal(t, map[string]any{"id": "u1", "notify": true, "user_name": "Alice"}, flattenExample(example, args))
+	assert.Equal(t, example, flattenExample(example, args[:1]))
+}
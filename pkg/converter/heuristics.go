@@ -0,0 +1,110 @@
+package converter
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+)
+
+// namingSuffixes maps naming conventions of string fields to the format they imply
+// and a description template for fields without a description
+var namingSuffixes = []struct {
+	suffix      string
+	format      string
+	description string
+}{
+	{suffix: "id", description: "Identifier of the %s"},
+	{suffix: "at", format: "date-time", description: "The %s time, as an RFC 3339 date-time"},
+	{suffix: "url", format: "uri", description: "URL of the %s"},
+	{suffix: "uri", format: "uri", description: "URI of the %s"},
+}
+
+// inferFromNames fills in formats and descriptions of string arguments that follow
+// *_id, *_at and *_url naming conventions when the spec omits them
+func inferFromNames(args []models.Arg) {
+	for i := range args {
+		inferArg(args[i].Name, &args[i])
+	}
+}
+
+// inferArg applies the naming heuristics to an argument and its nested properties and items
+func inferArg(name string, arg *models.Arg) {
+	for propName, prop := range arg.Properties {
+		inferArg(propName, &prop)
+		arg.Properties[propName] = prop
+	}
+	if arg.Items != nil {
+		inferArg(name, arg.Items)
+	}
+	if arg.Type != "string" || arg.Format != "" {
+		return
+	}
+
+	words := splitName(name)
+	if len(words) == 0 {
+		return
+	}
+	last := words[len(words)-1]
+	for _, convention := range namingSuffixes {
+		if last != convention.suffix {
+			continue
+		}
+		// "at" alone is not a timestamp, while a bare "id" or "url" is meaningful
+		if len(words) == 1 && convention.format == "date-time" {
+			return
+		}
+		arg.Format = convention.format
+		if arg.Description == "" {
+			subject := strings.Join(words[:len(words)-1], " ")
+			if subject == "" {
+				subject = "resource"
+			}
+			arg.Description = fmt.Sprintf(convention.description, subject)
+		}
+		return
+	}
+}
+
+// splitName splits a snake_case, kebab-case or camelCase name into lower case words
+func splitName(name string) []string {
+	var words []string
+	var current []rune
+	runes := []rune(name)
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, strings.ToLower(string(current)))
+			current = nil
+		}
+	}
+	for i, r := range runes {
+		switch {
+		case r == '_' || r == '-' || r == '.' || unicode.IsSpace(r):
+			flush()
+			continue
+		case unicode.IsUpper(r) && i > 0:
+			// Start a word at a lower-to-upper change, and before the last capital of an acronym ("userURL" → "user", "url")
+			prevLower := unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])
+			acronymEnd := unicode.IsUpper(runes[i-1]) && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || acronymEnd {
+				flush()
+			}
+		}
+		current = append(current, r)
+	}
+	flush()
+	return words
+}
+
+// joinCamel joins lower case words in lowerCamelCase, e.g. "requests", "per", "minute" as "requestsPerMinute"
+func joinCamel(words []string) string {
+	var b strings.Builder
+	for i, word := range words {
+		if i > 0 && word != "" {
+			word = strings.ToUpper(word[:1]) + word[1:]
+		}
+		b.WriteString(word)
+	}
+	return b.String()
+}
@@ -0,0 +1,85 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitName(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected []string
+	}{
+		{name: "user_id", expected: []string{"user", "id"}},
+		{name: "createdAt", expected: []string{"created", "at"}},
+		{name: "callbackURL", expected: []string{"callback", "url"}},
+		{name: "HTTPProxyUrl", expected: []string{"http", "proxy", "url"}},
+		{name: "X-Request-ID", expected: []string{"x", "request", "id"}},
+		{name: "id", expected: []string{"id"}},
+		{name: "", expected: nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, splitName(tc.name))
+		})
+	}
+}
+
+func TestInferFromNames(t *testing.T) {
+	tests := []struct {
+		name     string
+		arg      models.Arg
+		expected models.Arg
+	}{
+		{
+			name:     "Identifier",
+			arg:      models.Arg{Name: "user_id", Type: "string"},
+			expected: models.Arg{Name: "user_id", Type: "string", Description: "Identifier of the user"},
+		},
+		{
+			name:     "Bare identifier",
+			arg:      models.Arg{Name: "id", Type: "string"},
+			expected: models.Arg{Name: "id", Type: "string", Description: "Identifier of the resource"},
+		},
+		{
+			name:     "Timestamp keeps description",
+			arg:      models.Arg{Name: "expiresAt", Type: "string", Description: "Expiry"},
+			expected: models.Arg{Name: "expiresAt", Type: "string", Description: "Expiry", Format: "date-time"},
+		},
+		{
+			name:     "Declared format wins",
+			arg:      models.Arg{Name: "born_at", Type: "string", Format: "date"},
+			expected: models.Arg{Name: "born_at", Type: "string", Format: "date"},
+		},
+		{
+			name:     "Non-string type",
+			arg:      models.Arg{Name: "user_id", Type: "integer"},
+			expected: models.Arg{Name: "user_id", Type: "integer"},
+		},
+		{
+			name:     "Lone at",
+			arg:      models.Arg{Name: "at", Type: "string"},
+			expected: models.Arg{Name: "at", Type: "string"},
+		},
+		{
+			name: "Nested properties",
+			arg: models.Arg{Name: "profile", Type: "object", Properties: map[string]models.Arg{
+				"avatar_url": {Name: "avatar_url", Type: "string"},
+			}},
+			expected: models.Arg{Name: "profile", Type: "object", Properties: map[string]models.Arg{
+				"avatar_url": {Name: "avatar_url", Type: "string", Format: "uri", Description: "URL of the avatar"},
+			}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			args := []models.Arg{tc.arg}
+			inferFromNames(args)
+			assert.Equal(t, tc.expected, args[0])
+		})
+	}
+}
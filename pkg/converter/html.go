@@ -0,0 +1,262 @@
+package converter
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Description formats supported by ConvertOptions.DescriptionFormat
+const (
+	DescriptionFormatRaw      = "raw"
+	DescriptionFormatMarkdown = "markdown"
+	DescriptionFormatText     = "text"
+)
+
+var (
+	htmlTagPattern    = regexp.MustCompile(`</?[a-zA-Z][^>]*>|&[a-zA-Z]+;|&#[0-9]+;`)
+	blankLinesPattern = regexp.MustCompile(`\n{3,}`)
+	spacesPattern     = regexp.MustCompile(`[ \t\r\n]+`)
+)
+
+// formatDescription converts HTML found in a description according to the configured description format
+func (c *Converter) formatDescription(description string) string {
+	switch c.options.DescriptionFormat {
+	case DescriptionFormatMarkdown:
+		return htmlToMarkdown(description, false)
+	case DescriptionFormatText:
+		return htmlToMarkdown(description, true)
+	default:
+		return description
+	}
+}
+
+// htmlToMarkdown converts an HTML fragment to Markdown, or to plain text if plain is set.
+// Strings without any HTML markup are returned unchanged.
+func htmlToMarkdown(s string, plain bool) string {
+	if !htmlTagPattern.MatchString(s) {
+		return s
+	}
+
+	w := &markdownWriter{plain: plain}
+	tokenizer := html.NewTokenizer(strings.NewReader(s))
+	for {
+		tt := tokenizer.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+		token := tokenizer.Token()
+		switch tt {
+		case html.TextToken:
+			w.text(token.Data)
+		case html.StartTagToken, html.SelfClosingTagToken:
+			w.open(token)
+			if tt == html.SelfClosingTagToken {
+				w.close(token.Data)
+			}
+		case html.EndTagToken:
+			w.close(token.Data)
+		}
+	}
+
+	out := blankLinesPattern.ReplaceAllString(w.sb.String(), "\n\n")
+	lines := strings.Split(out, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// markdownWriter accumulates Markdown output while walking HTML tokens
+type markdownWriter struct {
+	sb     strings.Builder
+	last   byte // last byte written
+	inLine bool // whether the current line has more than spaces
+	plain  bool
+
+	skip  int      // depth inside <script>/<style>
+	pre   int      // depth inside <pre>
+	lists []string // stack of open list tags ("ul" or "ol")
+	items []int    // item counters for ordered lists
+	hrefs []string // stack of hrefs of open links
+}
+
+func (w *markdownWriter) open(token html.Token) {
+	switch token.Data {
+	case "script", "style":
+		w.skip++
+	case "p", "div", "section", "article", "table", "blockquote":
+		w.block()
+	case "br":
+		w.write("\n")
+	case "hr":
+		w.block()
+		if !w.plain {
+			w.write("---")
+		}
+		w.block()
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		w.block()
+		if !w.plain {
+			w.write(strings.Repeat("#", int(token.Data[1]-'0')) + " ")
+		}
+	case "strong", "b":
+		w.mark("**")
+	case "em", "i":
+		w.mark("*")
+	case "code":
+		if w.pre == 0 {
+			w.mark("`")
+		}
+	case "pre":
+		w.block()
+		if !w.plain {
+			w.write("```\n")
+		}
+		w.pre++
+	case "ul", "ol":
+		w.newline()
+		w.lists = append(w.lists, token.Data)
+		w.items = append(w.items, 0)
+	case "li":
+		w.newline()
+		depth := len(w.lists)
+		if depth > 1 {
+			w.write(strings.Repeat("  ", depth-1))
+		}
+		if depth > 0 && w.lists[depth-1] == "ol" {
+			w.items[depth-1]++
+			w.write(strconv.Itoa(w.items[depth-1]) + ". ")
+		} else {
+			w.write("- ")
+		}
+	case "tr":
+		w.newline()
+	case "td", "th":
+		w.write(" ")
+	case "a":
+		href := ""
+		for _, attr := range token.Attr {
+			if attr.Key == "href" {
+				href = attr.Val
+			}
+		}
+		w.hrefs = append(w.hrefs, href)
+		if href != "" && !w.plain {
+			w.write("[")
+		}
+	}
+}
+
+func (w *markdownWriter) close(tag string) {
+	switch tag {
+	case "script", "style":
+		if w.skip > 0 {
+			w.skip--
+		}
+	case "p", "div", "section", "article", "table", "blockquote",
+		"h1", "h2", "h3", "h4", "h5", "h6":
+		w.block()
+	case "strong", "b":
+		w.mark("**")
+	case "em", "i":
+		w.mark("*")
+	case "code":
+		if w.pre == 0 {
+			w.mark("`")
+		}
+	case "pre":
+		if w.pre > 0 {
+			w.pre--
+		}
+		w.newline()
+		if !w.plain {
+			w.write("```")
+		}
+		w.block()
+	case "ul", "ol":
+		if len(w.lists) > 0 {
+			w.lists = w.lists[:len(w.lists)-1]
+			w.items = w.items[:len(w.items)-1]
+		}
+		if len(w.lists) == 0 {
+			w.block()
+		}
+	case "a":
+		if len(w.hrefs) == 0 {
+			return
+		}
+		href := w.hrefs[len(w.hrefs)-1]
+		w.hrefs = w.hrefs[:len(w.hrefs)-1]
+		if href == "" {
+			return
+		}
+		if w.plain {
+			w.write(" (" + href + ")")
+		} else {
+			w.write("](" + href + ")")
+		}
+	}
+}
+
+func (w *markdownWriter) text(data string) {
+	if w.skip > 0 {
+		return
+	}
+	if w.pre > 0 {
+		w.write(data)
+		return
+	}
+	data = spacesPattern.ReplaceAllString(data, " ")
+	if strings.TrimSpace(data) == "" {
+		if w.sb.Len() > 0 && !w.atLineStart() && w.last != ' ' {
+			w.write(" ")
+		}
+		return
+	}
+	if w.atLineStart() {
+		data = strings.TrimLeft(data, " ")
+	}
+	w.write(data)
+}
+
+// mark writes an inline emphasis marker; it is dropped in plain text mode
+func (w *markdownWriter) mark(marker string) {
+	if !w.plain {
+		w.write(marker)
+	}
+}
+
+// block ends the current paragraph
+func (w *markdownWriter) block() {
+	if w.sb.Len() > 0 {
+		w.write("\n\n")
+	}
+}
+
+// newline starts a new line unless already at the beginning of one
+func (w *markdownWriter) newline() {
+	if w.sb.Len() > 0 && !w.atLineStart() {
+		w.write("\n")
+	}
+}
+
+func (w *markdownWriter) atLineStart() bool {
+	return !w.inLine
+}
+
+// write appends to the output, tracking its end so that it never has to be read back
+func (w *markdownWriter) write(s string) {
+	if s == "" {
+		return
+	}
+	w.sb.WriteString(s)
+	w.last = s[len(s)-1]
+	if i := strings.LastIndexByte(s, '\n'); i >= 0 {
+		w.inLine = strings.TrimLeft(s[i+1:], " ") != ""
+	} else if strings.TrimLeft(s, " ") != "" {
+		w.inLine = true
+	}
+}
@@ -0,0 +1,48 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTMLToMarkdown(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		markdown string
+		text     string
+	}{
+		{
+			name:     "No HTML",
+			input:    "List all pets",
+			markdown: "List all pets",
+			text:     "List all pets",
+		},
+		{
+			name:     "Inline formatting",
+			input:    "Returns <b>all</b> pets, see <a href=\"https://example.com/docs\">the docs</a> &amp; <code>limit</code>.",
+			markdown: "Returns **all** pets, see [the docs](https://example.com/docs) & `limit`.",
+			text:     "Returns all pets, see the docs (https://example.com/docs) & limit.",
+		},
+		{
+			name:     "Paragraphs and lists",
+			input:    "<p>Creates a pet.</p>\n<p>Rules:</p><ul><li>name is required</li><li>tag is optional</li></ul><ol><li>first</li><li>second</li></ol>",
+			markdown: "Creates a pet.\n\nRules:\n\n- name is required\n- tag is optional\n\n1. first\n2. second",
+			text:     "Creates a pet.\n\nRules:\n\n- name is required\n- tag is optional\n\n1. first\n2. second",
+		},
+		{
+			name:     "Headings, breaks and scripts",
+			input:    "<h2>Usage</h2>line one<br/>line two<script>alert(1)</script>",
+			markdown: "## Usage\n\nline one\nline two",
+			text:     "Usage\n\nline one\nline two",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.markdown, htmlToMarkdown(tc.input, false))
+			assert.Equal(t, tc.text, htmlToMarkdown(tc.input, true))
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package converter
+
+import (
+	"strings"
+)
+
+// localize returns the translation of a field found in its x-<field>-i18n extension
+// (e.g. x-description-i18n: {zh-CN: ..., en-US: ...}) for the configured language.
+// The fallback is returned when no language is configured or no translation matches.
+func (c *Converter) localize(extensions map[string]any, field, fallback string) string {
+	if c.options.Language == "" {
+		return fallback
+	}
+	translations, ok := extensions["x-"+field+"-i18n"].(map[string]any)
+	if !ok {
+		return fallback
+	}
+	if translation, ok := matchLanguage(translations, c.options.Language); ok {
+		return translation
+	}
+	return fallback
+}
+
+// matchLanguage looks up a translation for a language tag.
+// An exact match wins, then a case-insensitive match, then a match on the base language
+// (so "zh" matches "zh-CN" and "en-GB" matches "en").
+func matchLanguage(translations map[string]any, lang string) (string, bool) {
+	if translation, ok := translations[lang].(string); ok && translation != "" {
+		return translation, true
+	}
+
+	normalized := normalizeLanguage(lang)
+	base, _, _ := strings.Cut(normalized, "-")
+	var baseMatch string
+	for tag, value := range translations {
+		translation, ok := value.(string)
+		if !ok || translation == "" {
+			continue
+		}
+		candidate := normalizeLanguage(tag)
+		if candidate == normalized {
+			return translation, true
+		}
+		candidateBase, _, _ := strings.Cut(candidate, "-")
+		// Prefer the shortest matching tag so "zh" wins over "zh-TW" when asking for "zh-CN"
+		if candidateBase == base && (baseMatch == "" || len(tag) < len(baseMatch) || (len(tag) == len(baseMatch) && tag < baseMatch)) {
+			baseMatch = tag
+		}
+	}
+	if baseMatch != "" {
+		return translations[baseMatch].(string), true
+	}
+	return "", false
+}
+
+// normalizeLanguage lowercases a language tag and uses "-" as separator
+func normalizeLanguage(lang string) string {
+	return strings.ToLower(strings.ReplaceAll(lang, "_", "-"))
+}
@@ -0,0 +1,163 @@
+package converter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+)
+
+// SetBaseline enables incremental conversion: Convert keeps the tools of the previous configuration
+// whose operations have the hash recorded in the lock, and records the hashes of the new tools
+// for LockFile. The previous configuration and lock may be nil, e.g. on the first conversion.
+func (c *Converter) SetBaseline(previous *models.MCPConfig, lock *models.LockFile) {
+	c.incremental = true
+	c.baseline = previous
+	c.baselineLock = lock
+}
+
+// LockFile returns the hash of the operation of each tool of the last incremental conversion
+func (c *Converter) LockFile() *models.LockFile {
+	return &models.LockFile{Settings: c.settingsHash, Tools: c.hashes}
+}
+
+// loadDocumentTree decodes the document into generic JSON values, from which operations are hashed
+func (c *Converter) loadDocumentTree() error {
+	c.documentTree = nil
+	if !c.incremental {
+		return nil
+	}
+	data, err := json.Marshal(c.parser.GetDocument())
+	if err != nil {
+		return fmt.Errorf("failed to encode document: %w", err)
+	}
+	if err := json.Unmarshal(data, &c.documentTree); err != nil {
+		return fmt.Errorf("failed to decode document: %w", err)
+	}
+	return nil
+}
+
+// hashSettings hashes what applies to every tool besides its operation: the resolved options, the
+// template and transformer scripts they read, and the document-level security and servers
+func (c *Converter) hashSettings() error {
+	c.settingsHash = ""
+	if !c.incremental {
+		return nil
+	}
+	source := map[string]any{
+		"options":         c.options,
+		"security":        c.documentTree["security"],
+		"servers":         c.documentTree["servers"],
+		"securitySchemes": resolvePointer(c.documentTree, "#/components/securitySchemes"),
+	}
+	if c.options.TemplatePath != "" {
+		data, err := os.ReadFile(c.options.TemplatePath)
+		if err != nil {
+			return fmt.Errorf("failed to read template file: %w", err)
+		}
+		source["template"] = string(data)
+	}
+	transformers := make([]string, 0, len(c.transformers))
+	for _, transformer := range c.transformers {
+		description := fmt.Sprintf("%T", transformer)
+		if script, ok := transformer.(ScriptTransformer); ok {
+			data, err := os.ReadFile(script.Path)
+			if err != nil {
+				return fmt.Errorf("failed to read transform script: %w", err)
+			}
+			description += " " + script.Path + "\n" + string(data)
+		}
+		transformers = append(transformers, description)
+	}
+	source["transformers"] = transformers
+
+	data, err := json.Marshal(source)
+	if err != nil {
+		return fmt.Errorf("failed to encode conversion settings: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	c.settingsHash = hex.EncodeToString(sum[:])
+	return nil
+}
+
+// operationHash hashes an operation with the parameters of its path and the components it
+// references, directly or not, so that changing any of them changes the hash
+func (c *Converter) operationHash(path, method string) string {
+	paths, _ := c.documentTree["paths"].(map[string]any)
+	pathItem, _ := paths[path].(map[string]any)
+	source := map[string]any{
+		"path":       path,
+		"method":     method,
+		"operation":  pathItem[method],
+		"parameters": pathItem["parameters"],
+	}
+	refs := make(map[string]any)
+	collectRefs(source, c.documentTree, refs)
+	source["refs"] = refs
+
+	// Maps are encoded with sorted keys, so equal operations have equal hashes
+	data, _ := json.Marshal(source)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// collectRefs adds the local references found in a value, and those found in their targets, to refs
+func collectRefs(value any, root map[string]any, refs map[string]any) {
+	switch value := value.(type) {
+	case map[string]any:
+		if ref, ok := value["$ref"].(string); ok && strings.HasPrefix(ref, "#/") {
+			if _, seen := refs[ref]; !seen {
+				target := resolvePointer(root, ref)
+				refs[ref] = target
+				collectRefs(target, root, refs)
+			}
+		}
+		for _, item := range value {
+			collectRefs(item, root, refs)
+		}
+	case []any:
+		for _, item := range value {
+			collectRefs(item, root, refs)
+		}
+	}
+}
+
+// resolvePointer returns the value a local reference such as #/components/schemas/Pet points to
+func resolvePointer(root map[string]any, ref string) any {
+	var value any = root
+	for _, token := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		value = object[strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")]
+	}
+	return value
+}
+
+// restoreTools replaces the tools whose operation hash matches the baseline lock with their
+// previous version, so they are written unchanged. Nothing is restored when the settings changed.
+func (c *Converter) restoreTools(tools []models.Tool) {
+	if c.baseline == nil || c.baselineLock == nil || c.baselineLock.Settings != c.settingsHash {
+		return
+	}
+	previous := make(map[string]models.Tool, len(c.baseline.Tools))
+	for _, tool := range c.baseline.InlineSchemas().Tools {
+		previous[tool.Name] = tool
+	}
+	for i, tool := range tools {
+		hash, ok := c.baselineLock.Tools[tool.Name]
+		if !ok || hash != c.hashes[tool.Name] {
+			continue
+		}
+		if restored, ok := previous[tool.Name]; ok {
+			// The input schema is derived from the args again, like shared schemas
+			restored.InputSchema = nil
+			tools[i] = restored
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package converter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+const incrementalSpec = `{
+  "openapi": "3.0.0",
+  "info": {"title": "Pets", "version": "1.0.0"},
+  "servers": [{"url": "https://pets.example.com"}],
+  "paths": {
+    "/pets": {
+      "get": {"operationId": "listPets", "summary": "List pets", "responses": {"200": {"description": "OK"}}},
+      "post": {
+        "operationId": "createPet",
+        "summary": "Create a pet",
+        "requestBody": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}},
+        "responses": {"201": {"description": "Created"}}
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "Pet": {"type": "object", "properties": {"name": {"type": "string", "description": "Name of the pet"}}}
+    }
+  }
+}`
+
+// convertIncrementally converts a spec against a baseline, returning the configuration and its lock
+func convertIncrementally(t *testing.T, spec string, previous *models.MCPConfig, lock *models.LockFile) (*models.MCPConfig, *models.LockFile) {
+	p := parser.NewParser()
+	if !assert.NoError(t, p.Parse([]byte(spec))) {
+		t.FailNow()
+	}
+	c := NewConverter(p, models.ConvertOptions{ServerName: "pets"})
+	c.SetBaseline(previous, lock)
+	config, err := c.Convert()
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return config, c.LockFile()
+}
+
+func TestIncrementalConversion(t *testing.T) {
+	previous, lock := convertIncrementally(t, incrementalSpec, nil, nil)
+	assert.Len(t, lock.Tools, 2)
+	for _, hash := range lock.Tools {
+		assert.Len(t, hash, 64)
+	}
+
+	// Unchanged operations keep the tool of the previous configuration
+	previous.Tools[0].Description = "Create a pet (previous)"
+	previous.Tools[1].Description = "List pets (previous)"
+	config, next := convertIncrementally(t, incrementalSpec, previous, lock)
+	assert.Equal(t, lock, next)
+	assert.Equal(t, "Create a pet (previous)", config.Tools[0].Description)
+	assert.Equal(t, "List pets (previous)", config.Tools[1].Description)
+
+	// Changing a referenced schema regenerates the tools of the operations using it
+	changed := strings.Replace(incrementalSpec, "Name of the pet", "Name given to the pet", 1)
+	config, next = convertIncrementally(t, changed, previous, lock)
+	assert.NotEqual(t, lock.Tools["createPet"], next.Tools["createPet"])
+	assert.Equal(t, lock.Tools["listPets"], next.Tools["listPets"])
+	assert.Equal(t, "Create a pet", config.Tools[0].Description)
+	assert.Equal(t, "Name given to the pet", config.Tools[0].Args[0].Description)
+	assert.Equal(t, "List pets (previous)", config.Tools[1].Description)
+
+	// Without a lock, every tool is regenerated
+	config, _ = convertIncrementally(t, incrementalSpec, previous, nil)
+	assert.Equal(t, "List pets", config.Tools[1].Description)
+}
+
+func TestIncrementalConversionSettings(t *testing.T) {
+	convert := func(spec string, options models.ConvertOptions, previous *models.MCPConfig, lock *models.LockFile) (*models.MCPConfig, *models.LockFile) {
+		p := parser.NewParser()
+		if !assert.NoError(t, p.Parse([]byte(spec))) {
+			t.FailNow()
+		}
+		options.ServerName = "pets"
+		c := NewConverter(p, options)
+		c.SetBaseline(previous, lock)
+		config, err := c.Convert()
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		return config, c.LockFile()
+	}
+
+	previous, lock := convert(incrementalSpec, models.ConvertOptions{}, nil, nil)
+	assert.Len(t, lock.Settings, 64)
+	previous.Tools[1].Description = "List pets (previous)"
+
+	// Changing the options regenerates every tool
+	config, next := convert(incrementalSpec, models.ConvertOptions{DeriveAnnotations: true, MaxDescriptionLength: 5}, previous, lock)
+	assert.NotEqual(t, lock.Settings, next.Settings)
+	assert.Equal(t, lock.Tools, next.Tools)
+	assert.Equal(t, "List…", config.Tools[1].Description)
+	assert.NotNil(t, config.Tools[1].Annotations)
+
+	// So does changing the document servers or security
+	changed := strings.Replace(incrementalSpec, "https://pets.example.com", "https://pets.example.org", 1)
+	config, next = convert(changed, models.ConvertOptions{}, previous, lock)
+	assert.NotEqual(t, lock.Settings, next.Settings)
+	assert.Equal(t, "List pets", config.Tools[1].Description)
+
+	// Locks written before settings were hashed regenerate every tool
+	config, _ = convert(incrementalSpec, models.ConvertOptions{}, previous, &models.LockFile{Tools: lock.Tools})
+	assert.Equal(t, "List pets", config.Tools[1].Description)
+}
+
+func TestResolvePointer(t *testing.T) {
+	root := map[string]any{"paths": map[string]any{"/pets/{id}": map[string]any{"get": "operation"}}}
+	assert.Equal(t, "operation", resolvePointer(root, "#/paths/~1pets~1{id}/get"))
+	assert.Nil(t, resolvePointer(root, "#/paths/missing/get"))
+}
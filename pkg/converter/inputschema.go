@@ -0,0 +1,128 @@
+package converter
+
+import (
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+)
+
+// addInputSchemas sets the JSON Schema of the arguments of every tool, as listed by MCP servers in tools/list
+func addInputSchemas(config *models.MCPConfig) {
+	for i := range config.Tools {
+		config.Tools[i].InputSchema = InputSchema(config.Tools[i].Args, config.Schemas)
+	}
+}
+
+// InputSchema builds the JSON Schema of tool arguments. Args referencing shared schemas
+// reference a $defs entry, which is included for every schema used.
+func InputSchema(args []models.Arg, schemas map[string]models.Schema) map[string]any {
+	properties := make(map[string]any, len(args))
+	required := []string{}
+	refs := make(map[string]bool)
+	for _, arg := range args {
+		properties[arg.Name] = argJSONSchema(arg, refs)
+		if arg.Required {
+			required = append(required, arg.Name)
+		}
+	}
+	inputSchema := map[string]any{"type": "object", "properties": properties, "required": required}
+
+	// Schemas can reference each other, so definitions are added until no new reference is found
+	defs := make(map[string]any)
+	for len(defs) < len(refs) {
+		for name := range refs {
+			if _, ok := defs[name]; ok {
+				continue
+			}
+			schema := schemas[name]
+			defs[name] = argJSONSchema(models.Arg{Type: "object", Properties: schema.Properties}, refs)
+		}
+	}
+	if len(defs) > 0 {
+		inputSchema["$defs"] = defs
+	}
+	return inputSchema
+}
+
+// argJSONSchema converts an arg to JSON Schema, collecting the names of the shared schemas it references
+func argJSONSchema(arg models.Arg, refs map[string]bool) map[string]any {
+	if arg.Ref != "" {
+		refs[arg.Ref] = true
+		schema := map[string]any{"$ref": "#/$defs/" + arg.Ref}
+		if arg.Description != "" {
+			schema["description"] = arg.Description
+		}
+		return schema
+	}
+
+	schema := make(map[string]any)
+	if arg.Type != "" {
+		schema["type"] = arg.Type
+		// JSON Schema has no nullable keyword, null is allowed as a type instead
+		if arg.Nullable {
+			schema["type"] = []any{arg.Type, "null"}
+		}
+	}
+	if arg.Title != "" {
+		schema["title"] = arg.Title
+	}
+	if arg.Description != "" {
+		schema["description"] = arg.Description
+	}
+	if arg.Default != nil {
+		schema["default"] = arg.Default
+	}
+	if len(arg.Enum) > 0 {
+		schema["enum"] = arg.Enum
+	}
+	if len(arg.Examples) > 0 {
+		schema["examples"] = arg.Examples
+	}
+	if arg.Format != "" {
+		schema["format"] = arg.Format
+	}
+	if arg.Pattern != "" {
+		schema["pattern"] = arg.Pattern
+	}
+	if arg.Minimum != nil {
+		schema["minimum"] = *arg.Minimum
+	}
+	if arg.Maximum != nil {
+		schema["maximum"] = *arg.Maximum
+	}
+	if arg.MinLength > 0 {
+		schema["minLength"] = arg.MinLength
+	}
+	if arg.MaxLength != nil {
+		schema["maxLength"] = *arg.MaxLength
+	}
+	if arg.MinItems > 0 {
+		schema["minItems"] = arg.MinItems
+	}
+	if arg.MaxItems != nil {
+		schema["maxItems"] = *arg.MaxItems
+	}
+	if arg.UniqueItems {
+		schema["uniqueItems"] = true
+	}
+	if arg.Items != nil {
+		schema["items"] = argJSONSchema(*arg.Items, refs)
+	}
+	if arg.AdditionalProperties != nil {
+		schema["additionalProperties"] = argJSONSchema(*arg.AdditionalProperties, refs)
+	}
+	if arg.Properties != nil {
+		properties := make(map[string]any, len(arg.Properties))
+		var required []string
+		for _, name := range sortedKeys(arg.Properties) {
+			property := arg.Properties[name]
+			properties[name] = argJSONSchema(property, refs)
+			if property.Required {
+				required = append(required, name)
+			}
+		}
+		schema["properties"] = properties
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+	}
+	return schema
+}
@@ -0,0 +1,104 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+)
+
+func TestInputSchema(t *testing.T) {
+	maxLength := uint64(10)
+	tests := []struct {
+		name     string
+		args     []models.Arg
+		schemas  map[string]models.Schema
+		expected map[string]any
+	}{
+		{
+			name:     "no args",
+			expected: map[string]any{"type": "object", "properties": map[string]any{}, "required": []string{}},
+		},
+		{
+			name: "scalar args",
+			args: []models.Arg{
+				{Name: "id", Description: "Pet ID", Type: "integer", Required: true, Position: "path"},
+				{Name: "tag", Type: "string", Nullable: true, MaxLength: &maxLength, Examples: []any{"dog"}, Position: "query"},
+			},
+			expected: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"id":  map[string]any{"type": "integer", "description": "Pet ID"},
+					"tag": map[string]any{"type": []any{"string", "null"}, "maxLength": uint64(10), "examples": []any{"dog"}},
+				},
+				"required": []string{"id"},
+			},
+		},
+		{
+			name: "nested objects",
+			args: []models.Arg{
+				{Name: "owner", Type: "object", Properties: map[string]models.Arg{
+					"name": {Name: "name", Type: "string", Required: true},
+					"tags": {Name: "tags", Type: "array", Items: &models.Arg{Type: "string"}},
+				}},
+				{Name: "labels", Type: "object", AdditionalProperties: &models.Arg{Type: "string"}},
+			},
+			expected: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"owner": map[string]any{
+						"type": "object",
+						"properties": map[string]any{
+							"name": map[string]any{"type": "string"},
+							"tags": map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+						},
+						"required": []string{"name"},
+					},
+					"labels": map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "string"}},
+				},
+				"required": []string{},
+			},
+		},
+		{
+			name: "shared schemas referencing each other",
+			args: []models.Arg{
+				{Name: "customer", Type: "object", Ref: "Customer"},
+			},
+			schemas: map[string]models.Schema{
+				"Customer": {Properties: map[string]models.Arg{
+					"address": {Name: "address", Type: "object", Ref: "Address"},
+				}},
+				"Address": {Properties: map[string]models.Arg{
+					"city": {Name: "city", Type: "string"},
+				}},
+				"Unused": {Properties: map[string]models.Arg{
+					"id": {Name: "id", Type: "string"},
+				}},
+			},
+			expected: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"customer": map[string]any{"$ref": "#/$defs/Customer"},
+				},
+				"required": []string{},
+				"$defs": map[string]any{
+					"Customer": map[string]any{
+						"type":       "object",
+						"properties": map[string]any{"address": map[string]any{"$ref": "#/$defs/Address"}},
+					},
+					"Address": map[string]any{
+						"type":       "object",
+						"properties": map[string]any{"city": map[string]any{"type": "string"}},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, InputSchema(tt.args, tt.schemas))
+		})
+	}
+}
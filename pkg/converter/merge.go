@@ -0,0 +1,440 @@
+package converter
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+)
+
+// Merge policies decide what happens when specs being merged conflict with each other
+const (
+	// MergePolicyError fails the merge on the first conflict
+	MergePolicyError = "error"
+	// MergePolicyPreferFirst keeps the definition from the spec listed first
+	MergePolicyPreferFirst = "prefer-first"
+	// MergePolicyPreferLast keeps the definition from the spec listed last
+	MergePolicyPreferLast = "prefer-last"
+	// MergePolicyRenameWithPrefix keeps both definitions, prefixing the later one with its source name
+	MergePolicyRenameWithPrefix = "rename-with-prefix"
+)
+
+var nonIdentifierPattern = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// configReferencePattern matches the references of templates to server config values, e.g. .config.apiKey
+var configReferencePattern = regexp.MustCompile(`\.config\.([A-Za-z_][A-Za-z0-9_]*)`)
+
+// MergeSource is a converted MCP configuration together with the name of the spec it came from
+type MergeSource struct {
+	Name   string
+	Config *models.MCPConfig
+}
+
+// MergeConfigs merges the configurations converted from several specs into a single configuration.
+// Conflicts are resolved according to policy:
+//   - tools with the same name, or with the same method and URL
+//   - security schemes with the same ID but a different definition
+//   - different server base URLs
+//
+// The tools of a spec whose server is not kept get absolute URLs, and rename-with-prefix points the
+// templates of a spec at the server config values it renames.
+func MergeConfigs(sources []MergeSource, policy string) (*models.MCPConfig, error) {
+	switch policy {
+	case "":
+		policy = MergePolicyError
+	case MergePolicyError, MergePolicyPreferFirst, MergePolicyPreferLast, MergePolicyRenameWithPrefix:
+	default:
+		return nil, fmt.Errorf("unknown merge policy %q", policy)
+	}
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("no configurations to merge")
+	}
+
+	first := sources[0].Config
+	merged := &models.MCPConfig{
+		ToolSet:  first.ToolSet,
+		Metadata: first.Metadata,
+		Server: models.ServerConfig{
+			Name:            first.Server.Name,
+			BaseURL:         first.Server.BaseURL,
+			Config:          make(map[string]any),
+			SecuritySchemes: []models.SecurityScheme{},
+		},
+		Tools: []models.Tool{},
+	}
+
+	prefixes := sourcePrefixes(sources)
+	// An empty allowTools allows every tool, so the merged list is only set when a spec restricts its
+	// tools, from whether the spec of each merged tool allows it
+	restricted := slices.ContainsFunc(sources, func(source MergeSource) bool {
+		return len(source.Config.Server.AllowTools) > 0
+	})
+	allowed := make(map[string]bool)
+
+	// Shared schemas are inlined, then shared again across the merged tools under their original names
+	var schemaNames map[string]string
+	for i, source := range sources {
+		config := source.Config.InlineSchemas()
+		for signature, name := range schemaNameIndex(source.Config.Schemas) {
+			if schemaNames == nil {
+				schemaNames = make(map[string]string)
+			}
+			if _, ok := schemaNames[signature]; !ok {
+				schemaNames[signature] = name
+			}
+		}
+		prefix := prefixes[i]
+		allows := func(name string) bool {
+			return len(config.Server.AllowTools) == 0 || contains(config.Server.AllowTools, name)
+		}
+		tools := make([]models.Tool, len(config.Tools))
+		copy(tools, config.Tools)
+
+		// Resolve server base URL conflicts
+		if config.Server.BaseURL != merged.Server.BaseURL {
+			switch {
+			case merged.Server.BaseURL == "":
+				merged.Server.BaseURL = config.Server.BaseURL
+			case config.Server.BaseURL == "":
+			case policy == MergePolicyError:
+				return nil, fmt.Errorf("spec %s uses server %s which conflicts with %s", source.Name, config.Server.BaseURL, merged.Server.BaseURL)
+			case policy == MergePolicyPreferLast:
+				// The tools merged so far keep their server by making their URLs absolute
+				for i := range merged.Tools {
+					merged.Tools[i].RequestTemplate.URL = joinURL(merged.Server.BaseURL, merged.Tools[i].RequestTemplate.URL)
+				}
+				merged.Server.BaseURL = config.Server.BaseURL
+			default:
+				// Keep the spec's own server by making its tool URLs absolute
+				for i := range tools {
+					tools[i].RequestTemplate.URL = joinURL(config.Server.BaseURL, tools[i].RequestTemplate.URL)
+				}
+			}
+		}
+
+		// Merge server config values
+		renamedConfig := make(map[string]string)
+		for k, v := range config.Server.Config {
+			if existing, ok := merged.Server.Config[k]; ok && !reflect.DeepEqual(existing, v) {
+				switch policy {
+				case MergePolicyError:
+					return nil, fmt.Errorf("spec %s sets server config %q which conflicts with a previous spec", source.Name, k)
+				case MergePolicyPreferFirst:
+					continue
+				case MergePolicyRenameWithPrefix:
+					if _, ok := merged.Server.Config[prefix+k]; ok {
+						return nil, fmt.Errorf("spec %s sets server config %q which still conflicts after renaming", source.Name, prefix+k)
+					}
+					renamedConfig[k] = prefix + k
+					k = prefix + k
+				}
+			}
+			merged.Server.Config[k] = v
+		}
+
+		// Merge security schemes
+		renamedSchemes := make(map[string]string)
+		for _, scheme := range config.Server.SecuritySchemes {
+			scheme.DefaultCredential = renameConfigReferences(scheme.DefaultCredential, renamedConfig)
+			index := findSecurityScheme(merged.Server.SecuritySchemes, scheme.ID)
+			if index < 0 {
+				merged.Server.SecuritySchemes = append(merged.Server.SecuritySchemes, scheme)
+				continue
+			}
+			if reflect.DeepEqual(merged.Server.SecuritySchemes[index], scheme) {
+				continue
+			}
+			switch policy {
+			case MergePolicyError:
+				return nil, fmt.Errorf("spec %s defines security scheme %q which conflicts with a previous spec", source.Name, scheme.ID)
+			case MergePolicyPreferLast:
+				merged.Server.SecuritySchemes[index] = scheme
+			case MergePolicyRenameWithPrefix:
+				if findSecurityScheme(merged.Server.SecuritySchemes, prefix+scheme.ID) >= 0 {
+					return nil, fmt.Errorf("spec %s defines security scheme %q which still conflicts after renaming", source.Name, prefix+scheme.ID)
+				}
+				renamedSchemes[scheme.ID] = prefix + scheme.ID
+				scheme.ID = prefix + scheme.ID
+				merged.Server.SecuritySchemes = append(merged.Server.SecuritySchemes, scheme)
+			}
+		}
+
+		// Merge tools
+		renamedTools := make(map[string]string)
+		for _, tool := range tools {
+			renameSecurityReferences(&tool, renamedSchemes)
+			renameToolConfigReferences(&tool, renamedConfig)
+
+			index := findConflictingTool(merged.Tools, tool)
+			if index < 0 {
+				merged.Tools = append(merged.Tools, tool)
+				allowed[tool.Name] = allows(tool.Name)
+				continue
+			}
+			switch policy {
+			case MergePolicyError:
+				existing := merged.Tools[index]
+				return nil, fmt.Errorf("spec %s defines tool %s (%s %s) which conflicts with tool %s (%s %s)",
+					source.Name, tool.Name, tool.RequestTemplate.Method, tool.RequestTemplate.URL,
+					existing.Name, existing.RequestTemplate.Method, existing.RequestTemplate.URL)
+			case MergePolicyPreferLast:
+				delete(allowed, merged.Tools[index].Name)
+				merged.Tools[index] = tool
+				allowed[tool.Name] = allows(tool.Name)
+			case MergePolicyRenameWithPrefix:
+				name := tool.Name
+				tool.Name = prefix + name
+				if hasTool(merged.Tools, tool.Name) {
+					return nil, fmt.Errorf("spec %s defines tool %s which still conflicts after renaming", source.Name, tool.Name)
+				}
+				renamedTools[name] = tool.Name
+				merged.Tools = append(merged.Tools, tool)
+				allowed[tool.Name] = allows(name)
+			}
+		}
+
+		// Merge resources
+		for _, resource := range config.Resources {
+			resource.URI = joinURL(config.Server.BaseURL, resource.URI)
+			index := findResource(merged.Resources, resource.URI)
+			if index < 0 {
+				merged.Resources = append(merged.Resources, resource)
+				continue
+			}
+			if reflect.DeepEqual(merged.Resources[index], resource) {
+				continue
+			}
+			switch policy {
+			case MergePolicyError:
+				return nil, fmt.Errorf("spec %s defines resource %s which conflicts with a previous spec", source.Name, resource.URI)
+			case MergePolicyPreferLast:
+				merged.Resources[index] = resource
+			}
+			// Resources are identified by URI, so rename-with-prefix keeps the first one like prefer-first
+		}
+
+		// Merge prompts
+		for _, prompt := range config.Prompts {
+			index := findPrompt(merged.Prompts, prompt.Name)
+			if index < 0 {
+				merged.Prompts = append(merged.Prompts, prompt)
+				continue
+			}
+			if reflect.DeepEqual(merged.Prompts[index], prompt) {
+				continue
+			}
+			switch policy {
+			case MergePolicyError:
+				return nil, fmt.Errorf("spec %s defines prompt %q which conflicts with a previous spec", source.Name, prompt.Name)
+			case MergePolicyPreferLast:
+				merged.Prompts[index] = prompt
+			case MergePolicyRenameWithPrefix:
+				prompt.Name = prefix + prompt.Name
+				if findPrompt(merged.Prompts, prompt.Name) >= 0 {
+					return nil, fmt.Errorf("spec %s defines prompt %q which still conflicts after renaming", source.Name, prompt.Name)
+				}
+				merged.Prompts = append(merged.Prompts, prompt)
+			}
+		}
+
+		// Merge events
+		for _, event := range config.Events {
+			if name, ok := renamedTools[event.Tool]; ok {
+				event.Tool = name
+			}
+			index := findEvent(merged.Events, event.Name)
+			if index < 0 {
+				merged.Events = append(merged.Events, event)
+				continue
+			}
+			if reflect.DeepEqual(merged.Events[index], event) {
+				continue
+			}
+			switch policy {
+			case MergePolicyError:
+				return nil, fmt.Errorf("spec %s defines event %q which conflicts with a previous spec", source.Name, event.Name)
+			case MergePolicyPreferLast:
+				merged.Events[index] = event
+			case MergePolicyRenameWithPrefix:
+				event.Name = prefix + event.Name
+				if findEvent(merged.Events, event.Name) >= 0 {
+					return nil, fmt.Errorf("spec %s defines event %q which still conflicts after renaming", source.Name, event.Name)
+				}
+				merged.Events = append(merged.Events, event)
+			}
+		}
+	}
+
+	sortSecuritySchemes(merged.Server.SecuritySchemes)
+	sortTools(merged.Tools)
+	if restricted {
+		merged.Server.AllowTools = []string{}
+		for _, tool := range merged.Tools {
+			if allowed[tool.Name] {
+				merged.Server.AllowTools = append(merged.Server.AllowTools, tool.Name)
+			}
+		}
+	}
+	sortResources(merged.Resources)
+	sortPrompts(merged.Prompts)
+	sortEvents(merged.Events)
+	if schemaNames != nil {
+		shareSchemas(merged, schemaNames)
+	}
+
+	return merged, nil
+}
+
+// findConflictingTool returns the index of a tool with the same name or the same method and URL, or -1
+func findConflictingTool(tools []models.Tool, tool models.Tool) int {
+	for i, existing := range tools {
+		if existing.Name == tool.Name {
+			return i
+		}
+		if existing.RequestTemplate.Method == tool.RequestTemplate.Method &&
+			existing.RequestTemplate.URL == tool.RequestTemplate.URL {
+			return i
+		}
+	}
+	return -1
+}
+
+// hasTool checks if a tool with the given name exists
+func hasTool(tools []models.Tool, name string) bool {
+	return slices.ContainsFunc(tools, func(tool models.Tool) bool {
+		return tool.Name == name
+	})
+}
+
+// findSecurityScheme returns the index of the security scheme with the given ID, or -1
+func findSecurityScheme(schemes []models.SecurityScheme, id string) int {
+	for i, scheme := range schemes {
+		if scheme.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// findResource returns the index of the resource with the given URI, or -1
+func findResource(resources []models.Resource, uri string) int {
+	for i, resource := range resources {
+		if resource.URI == uri {
+			return i
+		}
+	}
+	return -1
+}
+
+// findPrompt returns the index of the prompt with the given name, or -1
+func findPrompt(prompts []models.Prompt, name string) int {
+	for i, prompt := range prompts {
+		if prompt.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// findEvent returns the index of the event with the given name, or -1
+func findEvent(events []models.Event, name string) int {
+	for i, event := range events {
+		if event.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// renameSecurityReferences points a tool's security requirements at renamed security schemes
+func renameSecurityReferences(tool *models.Tool, renamed map[string]string) {
+	if len(renamed) == 0 {
+		return
+	}
+	if tool.Security != nil {
+		if id, ok := renamed[tool.Security.ID]; ok {
+			security := *tool.Security
+			security.ID = id
+			tool.Security = &security
+		}
+	}
+	if tool.RequestTemplate.Security != nil {
+		if id, ok := renamed[tool.RequestTemplate.Security.ID]; ok {
+			security := *tool.RequestTemplate.Security
+			security.ID = id
+			tool.RequestTemplate.Security = &security
+		}
+	}
+}
+
+// renameToolConfigReferences points the templates of a tool at renamed server config values
+func renameToolConfigReferences(tool *models.Tool, renamed map[string]string) {
+	if len(renamed) == 0 {
+		return
+	}
+	request := &tool.RequestTemplate
+	request.URL = renameConfigReferences(request.URL, renamed)
+	request.Body = renameConfigReferences(request.Body, renamed)
+	request.Headers = slices.Clone(request.Headers)
+	for i := range request.Headers {
+		request.Headers[i].Value = renameConfigReferences(request.Headers[i].Value, renamed)
+	}
+	response := &tool.ResponseTemplate
+	response.Body = renameConfigReferences(response.Body, renamed)
+	response.PrependBody = renameConfigReferences(response.PrependBody, renamed)
+	response.AppendBody = renameConfigReferences(response.AppendBody, renamed)
+}
+
+// renameConfigReferences replaces the references to renamed server config values in a template,
+// e.g. {{.config.apiKey}} with {{.config.orders_apiKey}}
+func renameConfigReferences(text string, renamed map[string]string) string {
+	if len(renamed) == 0 {
+		return text
+	}
+	return configReferencePattern.ReplaceAllStringFunc(text, func(reference string) string {
+		if name, ok := renamed[strings.TrimPrefix(reference, ".config.")]; ok {
+			return ".config." + name
+		}
+		return reference
+	})
+}
+
+// sourcePrefix derives a name prefix from a spec source name
+func sourcePrefix(name string) string {
+	prefix := strings.Trim(nonIdentifierPattern.ReplaceAllString(name, "_"), "_")
+	if prefix == "" {
+		return ""
+	}
+	return prefix + "_"
+}
+
+// sourcePrefixes derives a distinct name prefix for each source, numbering the sources whose names give
+// the same prefix, e.g. users_ and users2_ for a/users.json and b/users.yaml
+func sourcePrefixes(sources []MergeSource) []string {
+	prefixes := make([]string, len(sources))
+	used := make(map[string]bool)
+	for i, source := range sources {
+		base := strings.TrimSuffix(sourcePrefix(source.Name), "_")
+		if base == "" {
+			base = "spec"
+		}
+		prefix := base + "_"
+		for n := 2; used[prefix]; n++ {
+			prefix = fmt.Sprintf("%s%d_", base, n)
+		}
+		used[prefix] = true
+		prefixes[i] = prefix
+	}
+	return prefixes
+}
+
+// joinURL joins a base URL and a path without doubling slashes
+func joinURL(baseURL, path string) string {
+	if baseURL == "" || strings.Contains(path, "://") {
+		return path
+	}
+	return strings.TrimRight(baseURL, "/") + "/" + strings.TrimLeft(path, "/")
+}
@@ -0,0 +1,175 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func mergeTestSources() []MergeSource {
+	return []MergeSource{
+		{
+			Name: "users",
+			Config: &models.MCPConfig{
+				Server: models.ServerConfig{
+					Name:    "users",
+					BaseURL: "http://users.example.com",
+					SecuritySchemes: []models.SecurityScheme{
+						{ID: "ApiKey", Type: "apiKey", In: "header", Name: "X-Users-Key"},
+					},
+				},
+				Tools: []models.Tool{
+					{
+						Name:            "getStatus",
+						RequestTemplate: models.RequestTemplate{URL: "/status", Method: "GET", Security: &models.ToolSecurityRequirement{ID: "ApiKey"}},
+					},
+					{
+						Name:            "listUsers",
+						RequestTemplate: models.RequestTemplate{URL: "/users", Method: "GET"},
+					},
+				},
+				Prompts: []models.Prompt{
+					{Name: "getStatus_example", Messages: []models.PromptMessage{{Role: "user", Content: "Check the users service"}}},
+				},
+			},
+		},
+		{
+			Name: "orders-api",
+			Config: &models.MCPConfig{
+				Server: models.ServerConfig{
+					Name:    "orders",
+					BaseURL: "http://orders.example.com",
+					SecuritySchemes: []models.SecurityScheme{
+						{ID: "ApiKey", Type: "apiKey", In: "header", Name: "X-Orders-Key"},
+					},
+				},
+				Tools: []models.Tool{
+					{
+						Name:            "getStatus",
+						RequestTemplate: models.RequestTemplate{URL: "/status", Method: "GET", Security: &models.ToolSecurityRequirement{ID: "ApiKey"}},
+					},
+					{
+						Name:            "listOrders",
+						RequestTemplate: models.RequestTemplate{URL: "/orders", Method: "GET"},
+					},
+				},
+				Prompts: []models.Prompt{
+					{Name: "getStatus_example", Messages: []models.PromptMessage{{Role: "user", Content: "Check the orders service"}}},
+				},
+			},
+		},
+	}
+}
+
+func toolNames(tools []models.Tool) []string {
+	names := make([]string, 0, len(tools))
+	for _, tool := range tools {
+		names = append(names, tool.Name)
+	}
+	return names
+}
+
+func TestMergeConfigs(t *testing.T) {
+	t.Run("error", func(t *testing.T) {
+		_, err := MergeConfigs(mergeTestSources(), MergePolicyError)
+		assert.ErrorContains(t, err, "conflicts")
+	})
+
+	t.Run("prefer-first", func(t *testing.T) {
+		config, err := MergeConfigs(mergeTestSources(), MergePolicyPreferFirst)
+		assert.NoError(t, err)
+		assert.Equal(t, "http://users.example.com", config.Server.BaseURL)
+		assert.Equal(t, []string{"getStatus", "listOrders", "listUsers"}, toolNames(config.Tools))
+		assert.Equal(t, "/status", config.Tools[0].RequestTemplate.URL)
+		assert.Equal(t, "http://orders.example.com/orders", config.Tools[1].RequestTemplate.URL)
+		assert.Equal(t, "/users", config.Tools[2].RequestTemplate.URL)
+		assert.Equal(t, "X-Users-Key", config.Server.SecuritySchemes[0].Name)
+	})
+
+	t.Run("prefer-last", func(t *testing.T) {
+		config, err := MergeConfigs(mergeTestSources(), MergePolicyPreferLast)
+		assert.NoError(t, err)
+		assert.Equal(t, "http://orders.example.com", config.Server.BaseURL)
+		assert.Equal(t, []string{"getStatus", "listOrders", "listUsers"}, toolNames(config.Tools))
+		assert.Equal(t, "/status", config.Tools[0].RequestTemplate.URL)
+		assert.Equal(t, "/orders", config.Tools[1].RequestTemplate.URL)
+		assert.Equal(t, "http://users.example.com/users", config.Tools[2].RequestTemplate.URL)
+		assert.Equal(t, "X-Orders-Key", config.Server.SecuritySchemes[0].Name)
+	})
+
+	t.Run("rename-with-prefix", func(t *testing.T) {
+		config, err := MergeConfigs(mergeTestSources(), MergePolicyRenameWithPrefix)
+		assert.NoError(t, err)
+		assert.Equal(t, "http://users.example.com", config.Server.BaseURL)
+		assert.Equal(t, []string{"getStatus", "listOrders", "listUsers", "orders_api_getStatus"}, toolNames(config.Tools))
+
+		renamed := config.Tools[3]
+		assert.Equal(t, "http://orders.example.com/status", renamed.RequestTemplate.URL)
+		assert.Equal(t, "orders_api_ApiKey", renamed.RequestTemplate.Security.ID)
+		assert.Len(t, config.Server.SecuritySchemes, 2)
+		assert.Len(t, config.Prompts, 2)
+		assert.Equal(t, "orders_api_getStatus_example", config.Prompts[1].Name)
+	})
+
+	t.Run("rename-with-prefix config", func(t *testing.T) {
+		sources := mergeTestSources()
+		sources[0].Config.Server.Config = map[string]any{"apiKey": "users-key"}
+		sources[1].Config.Server.Config = map[string]any{"apiKey": "orders-key"}
+		sources[1].Config.Tools[1].RequestTemplate.Headers = []models.Header{{Key: "X-Orders-Key", Value: "{{.config.apiKey}}"}}
+		config, err := MergeConfigs(sources, MergePolicyRenameWithPrefix)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{"apiKey": "users-key", "orders_api_apiKey": "orders-key"}, config.Server.Config)
+		assert.Equal(t, "{{.config.orders_api_apiKey}}", config.Tools[1].RequestTemplate.Headers[0].Value)
+		assert.Equal(t, "{{.config.apiKey}}", sources[1].Config.Tools[1].RequestTemplate.Headers[0].Value)
+	})
+
+	t.Run("allow tools", func(t *testing.T) {
+		sources := mergeTestSources()
+		sources[1].Config.Server.AllowTools = []string{"getStatus"}
+		sources[1].Config.Events = []models.Event{{Name: "statusChanged", Tool: "getStatus"}}
+		config, err := MergeConfigs(sources, MergePolicyRenameWithPrefix)
+		assert.NoError(t, err)
+		// The spec without an allow list keeps all its tools, and renamed tools stay allowed
+		assert.Equal(t, []string{"getStatus", "listUsers", "orders_api_getStatus"}, config.Server.AllowTools)
+		assert.Equal(t, "orders_api_getStatus", config.Events[0].Tool)
+
+		config, err = MergeConfigs(mergeTestSources(), MergePolicyRenameWithPrefix)
+		assert.NoError(t, err)
+		assert.Empty(t, config.Server.AllowTools)
+	})
+
+	t.Run("rename-with-prefix same source names", func(t *testing.T) {
+		sources := mergeTestSources()
+		sources[0].Name, sources[1].Name = "users", "users"
+		config, err := MergeConfigs(sources, MergePolicyRenameWithPrefix)
+		assert.NoError(t, err)
+		assert.Equal(t, "users2_getStatus", config.Tools[3].Name)
+		assert.Equal(t, "users2_ApiKey", config.Tools[3].RequestTemplate.Security.ID)
+		assert.Equal(t, "users2_getStatus_example", config.Prompts[1].Name)
+
+		sources = mergeTestSources()
+		sources[0].Name, sources[1].Name = "", "..."
+		config, err = MergeConfigs(sources, MergePolicyRenameWithPrefix)
+		assert.NoError(t, err)
+		assert.Equal(t, "spec2_getStatus", config.Tools[3].Name)
+	})
+
+	t.Run("rename-with-prefix still conflicting", func(t *testing.T) {
+		sources := mergeTestSources()
+		sources[0].Config.Server.SecuritySchemes = append(sources[0].Config.Server.SecuritySchemes,
+			models.SecurityScheme{ID: "orders_api_ApiKey", Type: "http", Scheme: "bearer"})
+		_, err := MergeConfigs(sources, MergePolicyRenameWithPrefix)
+		assert.EqualError(t, err, `spec orders-api defines security scheme "orders_api_ApiKey" which still conflicts after renaming`)
+
+		sources = mergeTestSources()
+		sources[0].Config.Prompts = append(sources[0].Config.Prompts, models.Prompt{Name: "orders_api_getStatus_example"})
+		_, err = MergeConfigs(sources, MergePolicyRenameWithPrefix)
+		assert.EqualError(t, err, `spec orders-api defines prompt "orders_api_getStatus_example" which still conflicts after renaming`)
+	})
+
+	t.Run("unknown policy", func(t *testing.T) {
+		_, err := MergeConfigs(mergeTestSources(), "coin-flip")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,50 @@
+package converter
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/version"
+)
+
+// buildMetadata describes the generator binary and the options used for the conversion
+func (c *Converter) buildMetadata() *models.Metadata {
+	return &models.Metadata{
+		Generator: models.GeneratorInfo{
+			Name:      version.Name,
+			Version:   version.Version,
+			Commit:    version.Commit,
+			BuildDate: version.BuildDate,
+		},
+		Options: optionsSummary(c.options),
+	}
+}
+
+// optionsSummary returns the conversion options that are set, keyed by their JSON name
+func optionsSummary(options models.ConvertOptions) map[string]any {
+	data, err := json.Marshal(options)
+	if err != nil {
+		return nil
+	}
+	var all map[string]any
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil
+	}
+
+	// The flag requesting metadata is implied by its presence, and concurrency doesn't affect the output
+	delete(all, "emitMetadata")
+	delete(all, "concurrency")
+
+	summary := make(map[string]any)
+	for key, value := range all {
+		if value == nil || reflect.ValueOf(value).IsZero() {
+			continue
+		}
+		if m, ok := value.(map[string]any); ok && len(m) == 0 {
+			continue
+		}
+		summary[key] = value
+	}
+	return summary
+}
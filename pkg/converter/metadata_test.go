@@ -0,0 +1,31 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/parser"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/version"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetadata(t *testing.T) {
+	p := parser.NewParser()
+	assert.NoError(t, p.ParseFile("../../test/petstore.json"))
+
+	config, err := NewConverter(p, models.ConvertOptions{
+		ServerName:           "petstore",
+		ToolNamePrefix:       "pets_",
+		MaxDescriptionLength: 80,
+		EmitMetadata:         true,
+	}).Convert()
+	assert.NoError(t, err)
+
+	assert.Equal(t, version.Name, config.Metadata.Generator.Name)
+	assert.Equal(t, version.Version, config.Metadata.Generator.Version)
+	assert.Equal(t, map[string]any{
+		"serverName":           "petstore",
+		"toolNamePrefix":       "pets_",
+		"maxDescriptionLength": float64(80),
+	}, config.Metadata.Options)
+}
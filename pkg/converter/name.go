@@ -0,0 +1,72 @@
+package converter
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// Server name sources
+const (
+	NameFromTitle        = "title"
+	NameFromTitleVersion = "title-version"
+)
+
+// defaultServerName is used when no name can be derived from the document
+const defaultServerName = "openapi-server"
+
+// nameData is the data available to server name templates
+type nameData struct {
+	Title       string
+	Version     string
+	Description string
+}
+
+// serverName returns the ServerName option if set, else derives the name from the document info as
+// selected by the NameFrom option: "title" (default), "title-version" or a Go template such as
+// "{{.Title}}-v{{.Version}}". Titles are turned into lowercase words joined by dashes.
+func (c *Converter) serverName() (string, error) {
+	if c.options.ServerName != "" {
+		return c.options.ServerName, nil
+	}
+
+	var data nameData
+	if info := c.parser.GetDocument().Info; info != nil {
+		data = nameData{Title: info.Title, Version: info.Version, Description: info.Description}
+	}
+
+	var name string
+	switch nameFrom := c.options.NameFrom; {
+	case nameFrom == "" || nameFrom == NameFromTitle:
+		name = nameSlug(data.Title)
+	case nameFrom == NameFromTitleVersion:
+		if name = nameSlug(data.Title); name != "" && data.Version != "" {
+			name += "-" + data.Version
+		}
+	case strings.Contains(nameFrom, "{{"):
+		tmpl, err := template.New("name").Parse(nameFrom)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse server name template: %w", err)
+		}
+		var buffer bytes.Buffer
+		if err := tmpl.Execute(&buffer, data); err != nil {
+			return "", fmt.Errorf("failed to execute server name template: %w", err)
+		}
+		name = strings.TrimSpace(buffer.String())
+	default:
+		return "", fmt.Errorf("unknown server name source %q, expected %s, %s or a template such as \"{{.Title}}\"", nameFrom, NameFromTitle, NameFromTitleVersion)
+	}
+
+	if name == "" {
+		return defaultServerName, nil
+	}
+	return name, nil
+}
+
+// nameSlug lowercases a title and joins its words with dashes, e.g. "Petstore API" becomes "petstore-api"
+func nameSlug(title string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	}), "-")
+}
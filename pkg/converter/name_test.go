@@ -0,0 +1,51 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServerName(t *testing.T) {
+	spec := `{
+  "openapi": "3.0.0",
+  "info": {"title": "Petstore API", "version": "1.2.0", "description": "A sample API that uses a petstore as an example"},
+  "paths": {}
+}`
+	untitled := `{"openapi": "3.0.0", "info": {"title": "", "version": "1.0.0"}, "paths": {}}`
+
+	tests := []struct {
+		name     string
+		spec     string
+		options  models.ConvertOptions
+		expected string
+		wantErr  string
+	}{
+		{name: "Title by default", spec: spec, expected: "petstore-api"},
+		{name: "Title", spec: spec, options: models.ConvertOptions{NameFrom: NameFromTitle}, expected: "petstore-api"},
+		{name: "Title and version", spec: spec, options: models.ConvertOptions{NameFrom: NameFromTitleVersion}, expected: "petstore-api-1.2.0"},
+		{name: "Template", spec: spec, options: models.ConvertOptions{NameFrom: "{{.Title}} v{{.Version}}"}, expected: "Petstore API v1.2.0"},
+		{name: "Explicit name wins", spec: spec, options: models.ConvertOptions{ServerName: "pets", NameFrom: NameFromTitleVersion}, expected: "pets"},
+		{name: "No title", spec: untitled, options: models.ConvertOptions{NameFrom: NameFromTitleVersion}, expected: "openapi-server"},
+		{name: "Unknown source", spec: spec, options: models.ConvertOptions{NameFrom: "description"}, wantErr: `unknown server name source "description", expected title, title-version or a template such as "{{.Title}}"`},
+		{name: "Invalid template", spec: spec, options: models.ConvertOptions{NameFrom: "{{.Name}}"}, wantErr: "failed to execute server name template"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := parser.NewParser()
+			p.SetValidation(false)
+			assert.NoError(t, p.Parse([]byte(tc.spec)))
+
+			config, err := NewConverter(p, tc.options).Convert()
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, config.Server.Name)
+		})
+	}
+}
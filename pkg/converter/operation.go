@@ -0,0 +1,59 @@
+package converter
+
+import (
+	"fmt"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+)
+
+// ConvertOperation converts a single operation of the document to a tool, as Convert does before
+// applying the template, transformers and tool ordering. Programs can use it to convert selected
+// operations and assemble their own configurations.
+func (c *Converter) ConvertOperation(path, method string, operation *openapi3.Operation) (*models.Tool, error) {
+	if err := c.prepare(); err != nil {
+		return nil, err
+	}
+	if operation == nil {
+		return nil, fmt.Errorf("no operation for %s %s", method, path)
+	}
+	return c.convertOperation(path, method, operation)
+}
+
+// ConvertParameters converts the parameters of an operation to tool args
+func (c *Converter) ConvertParameters(parameters openapi3.Parameters) ([]models.Arg, error) {
+	if err := c.prepare(); err != nil {
+		return nil, err
+	}
+	return c.convertParameters(parameters)
+}
+
+// ConvertRequestBody converts the request body of an operation to tool args
+func (c *Converter) ConvertRequestBody(requestBodyRef *openapi3.RequestBodyRef) ([]models.Arg, error) {
+	if err := c.prepare(); err != nil {
+		return nil, err
+	}
+	return c.convertRequestBody(requestBodyRef)
+}
+
+// prepare resolves the options with the x-mcp-options of the document and the profile, and
+// parses the description template. It does nothing once the converter is prepared.
+func (c *Converter) prepare() error {
+	if c.prepared {
+		return nil
+	}
+	if c.parser.GetDocument() == nil {
+		return fmt.Errorf("no OpenAPI document loaded")
+	}
+	options, err := c.resolveOptions(c.options)
+	if err != nil {
+		return err
+	}
+	c.options = options
+	if err := c.parseDescriptionTemplate(); err != nil {
+		return err
+	}
+	c.prepared = true
+	return nil
+}
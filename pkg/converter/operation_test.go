@@ -0,0 +1,56 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertOperation(t *testing.T) {
+	p := parser.NewParser()
+	if !assert.NoError(t, p.ParseFile("../../test/petstore.json")) {
+		return
+	}
+	c := NewConverter(p, models.ConvertOptions{ToolNamePrefix: "pets_"})
+
+	operation := p.GetPaths()["/pets"].Post
+	tool, err := c.ConvertOperation("/pets", "post", operation)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "pets_createPets", tool.Name)
+		assert.Equal(t, "/pets", tool.RequestTemplate.URL)
+		assert.Equal(t, "POST", tool.RequestTemplate.Method)
+		if assert.Len(t, tool.Args, 2) {
+			assert.Equal(t, "name", tool.Args[0].Name)
+			assert.True(t, tool.Args[0].Required)
+		}
+	}
+
+	args, err := c.ConvertRequestBody(operation.RequestBody)
+	if assert.NoError(t, err) {
+		assert.Len(t, args, 2)
+	}
+	args, err = c.ConvertParameters(p.GetPaths()["/pets/{petId}"].Get.Parameters)
+	if assert.NoError(t, err) && assert.Len(t, args, 1) {
+		assert.Equal(t, "petId", args[0].Name)
+		assert.Equal(t, "path", args[0].Position)
+	}
+
+	_, err = c.ConvertOperation("/pets", "put", nil)
+	assert.EqualError(t, err, "no operation for put /pets")
+}
+
+func TestConvertOperationResolvesOptions(t *testing.T) {
+	p := parser.NewParser()
+	if !assert.NoError(t, p.ParseFile("../../test/mcp-options.json")) {
+		return
+	}
+	tool, err := NewConverter(p, models.ConvertOptions{}).ConvertOperation("/items", "get", p.GetPaths()["/items"].Get)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "inventory_listItems", tool.Name)
+	}
+
+	_, err = NewConverter(parser.NewParser(), models.ConvertOptions{}).ConvertParameters(nil)
+	assert.EqualError(t, err, "no OpenAPI document loaded")
+}
@@ -0,0 +1,118 @@
+package converter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+)
+
+// optionsExtension is the document-level extension providing default conversion options
+const optionsExtension = "x-mcp-options"
+
+// Profiles are named sets of default conversion options
+var Profiles = map[string]models.ConvertOptions{
+	// compact keeps descriptions short to save context space
+	"compact": {
+		DescriptionFormat:       DescriptionFormatText,
+		DescriptionSummary:      true,
+		MaxDescriptionLength:    200,
+		MaxArgDescriptionLength: 100,
+	},
+	// rich exposes as much of the specification as possible
+	"rich": {
+		DescriptionFormat: DescriptionFormatMarkdown,
+		DeriveAnnotations: true,
+		EmitPrompts:       true,
+		GetAsResources:    true,
+		InferFormats:      true,
+	},
+	// strict fails the conversion on any warning
+	"strict": {
+		FailOnWarnings: WarningCategories,
+	},
+}
+
+// ProfileNames returns the names of the built-in profiles, sorted
+func ProfileNames() []string {
+	names := make([]string, 0, len(Profiles))
+	for name := range Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// specOptions are the options x-mcp-options can set. Options referring to local files or
+// credentials, such as templatePath, credentialFrom and passthrough, are left to the user.
+var specOptions = map[string]bool{
+	"serverName": true, "toolNamePrefix": true, "nameFrom": true, "baseURL": true, "prefixByTag": true,
+	"descriptionFormat": true, "maxDescriptionLength": true, "maxArgDescriptionLength": true,
+	"descriptionSummary": true, "descriptionTemplate": true, "language": true, "deriveAnnotations": true,
+	"responseMaxFields": true, "responseMaxDepth": true, "rateLimitInDescription": true, "emitEvents": true,
+	"emitMetadata": true, "getAsResources": true, "examplesInDescription": true, "inferFormats": true,
+	"emitPrompts": true, "foldConstants": true, "flattenBodyDepth": true, "sharedSchemas": true,
+	"emitInputSchema": true, "sort": true, "keepArgOrder": true, "failOnWarnings": true,
+	"warningsAsErrors": true, "filter": true, "profile": true,
+}
+
+// resolveOptions completes the options passed to the converter with the defaults from
+// the x-mcp-options extension of the document, then with the defaults of the selected profile.
+// Options that are already set always win.
+func (c *Converter) resolveOptions(options models.ConvertOptions) (models.ConvertOptions, error) {
+	if raw, ok := c.parser.GetDocument().Extensions[optionsExtension]; ok {
+		data, err := json.Marshal(raw)
+		if err != nil {
+			return options, fmt.Errorf("failed to read %s: %w", optionsExtension, err)
+		}
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return options, fmt.Errorf("failed to parse %s: %w", optionsExtension, err)
+		}
+		for name := range fields {
+			if !specOptions[name] {
+				return options, fmt.Errorf("option %q cannot be set in %s", name, optionsExtension)
+			}
+		}
+		var defaults models.ConvertOptions
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&defaults); err != nil {
+			return options, fmt.Errorf("failed to parse %s: %w", optionsExtension, err)
+		}
+		fillDefaults(reflect.ValueOf(&options).Elem(), reflect.ValueOf(defaults))
+	}
+
+	if options.Profile != "" {
+		profile, ok := Profiles[options.Profile]
+		if !ok {
+			return options, fmt.Errorf("unknown profile %q, expected one of %v", options.Profile, ProfileNames())
+		}
+		fillDefaults(reflect.ValueOf(&options).Elem(), reflect.ValueOf(profile))
+	}
+
+	switch options.DescriptionFormat {
+	case "", DescriptionFormatRaw, DescriptionFormatMarkdown, DescriptionFormatText:
+	default:
+		return options, fmt.Errorf("unknown description format %q, expected %s, %s or %s", options.DescriptionFormat,
+			DescriptionFormatRaw, DescriptionFormatMarkdown, DescriptionFormatText)
+	}
+	return options, nil
+}
+
+// fillDefaults sets the zero fields of dst to the values of defaults, recursing into structs
+func fillDefaults(dst, defaults reflect.Value) {
+	for i := 0; i < dst.NumField(); i++ {
+		field := dst.Field(i)
+		if field.Kind() == reflect.Struct {
+			fillDefaults(field, defaults.Field(i))
+			continue
+		}
+		if field.IsZero() {
+			field.Set(defaults.Field(i))
+		}
+	}
+}
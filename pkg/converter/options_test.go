@@ -0,0 +1,142 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveOptions(t *testing.T) {
+	spec := `{
+  "openapi": "3.0.0",
+  "info": {"title": "Options", "version": "1.0.0"},
+  "x-mcp-options": {
+    "serverName": "from-spec",
+    "toolNamePrefix": "spec_",
+    "profile": "compact",
+    "filter": {"includeTags": ["public"]}
+  },
+  "paths": {}
+}`
+
+	tests := []struct {
+		name     string
+		spec     string
+		options  models.ConvertOptions
+		expected models.ConvertOptions
+		wantErr  string
+	}{
+		{
+			name: "Spec and profile provide defaults",
+			spec: spec,
+			expected: models.ConvertOptions{
+				ServerName:              "from-spec",
+				ToolNamePrefix:          "spec_",
+				Profile:                 "compact",
+				Filter:                  models.Filter{IncludeTags: []string{"public"}},
+				DescriptionFormat:       DescriptionFormatText,
+				DescriptionSummary:      true,
+				MaxDescriptionLength:    200,
+				MaxArgDescriptionLength: 100,
+			},
+		},
+		{
+			name: "Explicit options win",
+			spec: spec,
+			options: models.ConvertOptions{
+				ServerName:           "from-flags",
+				Profile:              "rich",
+				MaxDescriptionLength: 500,
+				Filter:               models.Filter{ExcludeTags: []string{"internal"}},
+			},
+			expected: models.ConvertOptions{
+				ServerName:           "from-flags",
+				ToolNamePrefix:       "spec_",
+				Profile:              "rich",
+				MaxDescriptionLength: 500,
+				Filter:               models.Filter{IncludeTags: []string{"public"}, ExcludeTags: []string{"internal"}},
+				DescriptionFormat:    DescriptionFormatMarkdown,
+				DeriveAnnotations:    true,
+				EmitPrompts:          true,
+				GetAsResources:       true,
+				InferFormats:         true,
+			},
+		},
+		{
+			name:    "Unknown option in spec",
+			spec:    `{"openapi": "3.0.0", "info": {"title": "Options", "version": "1.0.0"}, "x-mcp-options": {"toolPrefix": "x_"}, "paths": {}}`,
+			wantErr: `option "toolPrefix" cannot be set in x-mcp-options`,
+		},
+		{
+			name:    "Template path in spec",
+			spec:    `{"openapi": "3.0.0", "info": {"title": "Options", "version": "1.0.0"}, "x-mcp-options": {"templatePath": "/tmp/template.yaml"}, "paths": {}}`,
+			wantErr: `option "templatePath" cannot be set in x-mcp-options`,
+		},
+		{
+			name:    "Unknown profile",
+			spec:    `{"openapi": "3.0.0", "info": {"title": "Options", "version": "1.0.0"}, "paths": {}}`,
+			options: models.ConvertOptions{Profile: "tiny"},
+			wantErr: `unknown profile "tiny", expected one of [compact rich strict]`,
+		},
+		{
+			name:    "Unknown description format",
+			spec:    `{"openapi": "3.0.0", "info": {"title": "Options", "version": "1.0.0"}, "x-mcp-options": {"descriptionFormat": "html"}, "paths": {}}`,
+			wantErr: `unknown description format "html", expected raw, markdown or text`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := parser.NewParser()
+			p.SetValidation(false)
+			assert.NoError(t, p.Parse([]byte(tc.spec)))
+
+			options, err := NewConverter(p, tc.options).resolveOptions(tc.options)
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, options)
+		})
+	}
+}
+
+func TestBaseURL(t *testing.T) {
+	withServers := `{"openapi": "3.0.0", "info": {"title": "Servers", "version": "1.0.0"}, "servers": [{"url": "https://{host}.internal"}], "paths": {}}`
+	withoutServers := `{"openapi": "3.0.0", "info": {"title": "Servers", "version": "1.0.0"}, "paths": {}}`
+	fromSpecOptions := `{"openapi": "3.0.0", "info": {"title": "Servers", "version": "1.0.0"}, "x-mcp-options": {"baseURL": "https://api.example.com"}, "paths": {}}`
+
+	tests := []struct {
+		name     string
+		spec     string
+		baseURL  string
+		expected string
+		wantErr  string
+	}{
+		{name: "Servers of the spec", spec: withServers, expected: "https://{host}.internal"},
+		{name: "Option overrides servers", spec: withServers, baseURL: "https://api.example.com/v2", expected: "https://api.example.com/v2"},
+		{name: "Option without servers", spec: withoutServers, baseURL: "http://localhost:8080", expected: "http://localhost:8080"},
+		{name: "No servers", spec: withoutServers, expected: ""},
+		{name: "Option in x-mcp-options", spec: fromSpecOptions, expected: "https://api.example.com"},
+		{name: "Relative URL", spec: withServers, baseURL: "/v2", wantErr: `invalid base URL "/v2", expected an absolute URL such as https://api.example.com`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := parser.NewParser()
+			p.SetValidation(false)
+			assert.NoError(t, p.Parse([]byte(tc.spec)))
+
+			config, err := NewConverter(p, models.ConvertOptions{BaseURL: tc.baseURL}).Convert()
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, config.Server.BaseURL)
+		})
+	}
+}
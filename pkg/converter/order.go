@@ -0,0 +1,93 @@
+package converter
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+)
+
+// Tool orders supported by ConvertOptions.Sort
+const (
+	SortAlpha = "alpha" // By name (default)
+	SortNone  = "none"  // In the order operations are declared in the document
+	SortTag   = "tag"   // Grouped by first tag, in the order tags are declared, then by name
+)
+
+// checkSort validates the tool order, which needs the parser to record the document order when it is none
+func (c *Converter) checkSort() error {
+	switch c.options.Sort {
+	case "", SortAlpha, SortTag:
+		return nil
+	case SortNone:
+		if !c.parser.HasOperationOrder() {
+			return fmt.Errorf("sort %q requires the document order, enable it with Parser.SetPreserveOrder", SortNone)
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown sort %q, expected %s, %s or %s", c.options.Sort, SortAlpha, SortNone, SortTag)
+}
+
+// sortItems puts the operations in the order they are declared in the document when the sort is none
+func (c *Converter) sortItems(items []operationItem) {
+	if c.options.Sort != SortNone {
+		return
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		a, _ := c.parser.OperationIndex(items[i].path, items[i].method)
+		b, _ := c.parser.OperationIndex(items[j].path, items[j].method)
+		return a < b
+	})
+}
+
+// orderTools sorts tools according to the sort option. tags maps tool names to the first tag of their operation.
+func (c *Converter) orderTools(tools []models.Tool, tags map[string]string) {
+	switch c.options.Sort {
+	case SortNone:
+		// Tools are already in document order
+	case SortTag:
+		rank := c.tagRanks()
+		sort.SliceStable(tools, func(i, j int) bool {
+			a, b := tags[tools[i].Name], tags[tools[j].Name]
+			if a != b {
+				return tagLess(a, b, rank)
+			}
+			return tools[i].Name < tools[j].Name
+		})
+	default:
+		sortTools(tools)
+	}
+}
+
+// ToolTags returns the first tag of the operation of each tool converted by the last
+// call to Convert, keyed by tool name. Untagged tools are mapped to "".
+func (c *Converter) ToolTags() map[string]string {
+	return c.tags
+}
+
+// tagRanks returns the position of the tags declared at the document level
+func (c *Converter) tagRanks() map[string]int {
+	rank := make(map[string]int)
+	for i, tag := range c.parser.GetDocument().Tags {
+		if tag != nil {
+			rank[tag.Name] = i
+		}
+	}
+	return rank
+}
+
+// tagLess orders declared tags first, in declaration order, then undeclared tags by name, then untagged tools
+func tagLess(a, b string, rank map[string]int) bool {
+	if a == "" || b == "" {
+		return a != ""
+	}
+	rankA, declaredA := rank[a]
+	rankB, declaredB := rank[b]
+	switch {
+	case declaredA && declaredB:
+		return rankA < rankB
+	case declaredA != declaredB:
+		return declaredA
+	}
+	return a < b
+}
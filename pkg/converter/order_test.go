@@ -0,0 +1,172 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+const orderSpec = `openapi: 3.0.0
+info:
+  title: Order API
+  version: 1.0.0
+tags:
+  - name: users
+  - name: admin
+paths:
+  /users/{userId}:
+    put:
+      operationId: updateUser
+      tags: [users]
+      parameters:
+        - name: userId
+          in: path
+          required: true
+          schema:
+            type: string
+        - name: dryRun
+          in: query
+          schema:
+            type: boolean
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+                email:
+                  type: string
+      responses:
+        "200":
+          description: Updated
+    get:
+      operationId: getUser
+      tags: [users]
+      responses:
+        "200":
+          description: OK
+  /health:
+    get:
+      operationId: health
+      responses:
+        "200":
+          description: OK
+  /audit:
+    get:
+      operationId: listAudit
+      tags: [reports]
+      responses:
+        "200":
+          description: OK
+  /admin/settings:
+    get:
+      operationId: getSettings
+      tags: [admin]
+      responses:
+        "200":
+          description: OK
+`
+
+func TestSort(t *testing.T) {
+	tests := []struct {
+		name     string
+		sort     string
+		expected []string
+	}{
+		{"default", "", []string{"getSettings", "getUser", "health", "listAudit", "updateUser"}},
+		{"alpha", SortAlpha, []string{"getSettings", "getUser", "health", "listAudit", "updateUser"}},
+		{"none", SortNone, []string{"updateUser", "getUser", "health", "listAudit", "getSettings"}},
+		{"tag", SortTag, []string{"getUser", "updateUser", "getSettings", "listAudit", "health"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := parser.NewParser()
+			p.SetPreserveOrder(true)
+			if !assert.NoError(t, p.Parse([]byte(orderSpec))) {
+				return
+			}
+
+			config, err := NewConverter(p, models.ConvertOptions{Sort: tt.sort, Concurrency: 2}).Convert()
+			if !assert.NoError(t, err) {
+				return
+			}
+			names := make([]string, 0, len(config.Tools))
+			for _, tool := range config.Tools {
+				names = append(names, tool.Name)
+			}
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
+
+func TestSortErrors(t *testing.T) {
+	p := parser.NewParser()
+	if !assert.NoError(t, p.Parse([]byte(orderSpec))) {
+		return
+	}
+
+	_, err := NewConverter(p, models.ConvertOptions{Sort: SortNone}).Convert()
+	assert.ErrorContains(t, err, "requires the document order")
+
+	_, err = NewConverter(p, models.ConvertOptions{Sort: "random"}).Convert()
+	assert.ErrorContains(t, err, `unknown sort "random"`)
+}
+
+func TestKeepArgOrder(t *testing.T) {
+	tests := []struct {
+		name         string
+		keepArgOrder bool
+		expected     []string
+	}{
+		{"sorted", false, []string{"dryRun", "email", "name", "userId"}},
+		{"declared", true, []string{"userId", "dryRun", "email", "name"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := parser.NewParser()
+			if !assert.NoError(t, p.Parse([]byte(orderSpec))) {
+				return
+			}
+
+			config, err := NewConverter(p, models.ConvertOptions{
+				KeepArgOrder: tt.keepArgOrder,
+				Filter:       models.Filter{IncludeOperations: []string{"updateUser"}},
+			}).Convert()
+			if !assert.NoError(t, err) || !assert.Len(t, config.Tools, 1) {
+				return
+			}
+			names := make([]string, 0, len(config.Tools[0].Args))
+			for _, arg := range config.Tools[0].Args {
+				names = append(names, arg.Name)
+			}
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
+
+func TestTagLess(t *testing.T) {
+	rank := map[string]int{"users": 0, "admin": 1}
+	tests := []struct {
+		a, b     string
+		expected bool
+	}{
+		{"users", "admin", true},
+		{"admin", "users", false},
+		{"admin", "reports", true},
+		{"reports", "admin", false},
+		{"billing", "reports", true},
+		{"reports", "", true},
+		{"", "users", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, tagLess(tt.a, tt.b, rank), "%q < %q", tt.a, tt.b)
+	}
+}
@@ -0,0 +1,140 @@
+package converter
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+)
+
+// operationItem is an operation waiting to be converted
+type operationItem struct {
+	path      string
+	method    string
+	pathItem  *openapi3.PathItem
+	operation *openapi3.Operation
+}
+
+// operationResult holds what an operation was converted to
+type operationResult struct {
+	tool     *models.Tool
+	resource *models.Resource
+	prompts  []models.Prompt
+	events   []models.Event
+	warnings []models.Warning
+	tag      string   // First tag of the operation
+	tags     []string // All tags of the operation
+	hash     string   // Hash of the operation, in incremental conversions
+	source   models.ToolSource
+	err      error
+}
+
+// convertOperations converts all operations of the document on a pool of workers.
+// Results are returned in the order of the operations, whatever the order the workers finish in.
+func (c *Converter) convertOperations(baseURL string) []operationResult {
+	items := c.operationItems()
+	results := make([]operationResult, len(items))
+
+	workers := c.options.Concurrency
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, len(items))
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = c.convertItem(items[i], baseURL)
+			}
+		}()
+	}
+	for i := range items {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
+
+// operationItems lists the operations selected by the filter, sorted by path and method
+// or in document order, and records the skipped ones
+func (c *Converter) operationItems() []operationItem {
+	paths := c.parser.GetPaths()
+	pathNames := make([]string, 0, len(paths))
+	for path := range paths {
+		pathNames = append(pathNames, path)
+	}
+	sort.Strings(pathNames)
+
+	var items []operationItem
+	for _, path := range pathNames {
+		pathItem := paths[path]
+		operations := getOperations(pathItem)
+		methods := make([]string, 0, len(operations))
+		for method := range operations {
+			methods = append(methods, method)
+		}
+		sort.Strings(methods)
+
+		for _, method := range methods {
+			operation := operations[method]
+			operationID := c.parser.GetOperationID(path, method, operation)
+			if reason := filterReason(c.options.Filter, path, method, operationID, operation); reason != "" {
+				c.skip(path, method, operationID, reason)
+				continue
+			}
+			items = append(items, operationItem{path: path, method: method, pathItem: pathItem, operation: operation})
+		}
+	}
+	c.sortItems(items)
+	return items
+}
+
+// convertItem converts a single operation to a resource or a tool with its prompts.
+// It works on a copy of the converter so that warnings are collected per operation.
+func (c *Converter) convertItem(item operationItem, baseURL string) operationResult {
+	worker := *c
+	worker.warnings = nil
+
+	if c.options.GetAsResources && isResourceOperation(item.path, item.method, item.pathItem, item.operation, c.parser.GetDocument().Security) {
+		resource := worker.convertResource(item.path, item.method, baseURL, item.operation)
+		return operationResult{resource: &resource, warnings: worker.warnings}
+	}
+
+	tool, err := worker.convertOperation(item.path, item.method, item.operation)
+	if err != nil {
+		return operationResult{err: fmt.Errorf("failed to convert operation %s %s: %w", item.method, item.path, err)}
+	}
+	worker.checkTool(tool, item.operation)
+
+	result := operationResult{tool: tool, source: models.ToolSource{
+		Method:      item.method,
+		Path:        item.path,
+		OperationID: c.parser.GetOperationID(item.path, item.method, item.operation),
+	}}
+	if c.incremental {
+		result.hash = worker.operationHash(item.path, item.method)
+	}
+	if len(item.operation.Tags) > 0 {
+		result.tag = item.operation.Tags[0]
+		result.tags = item.operation.Tags
+	}
+	if c.options.EmitPrompts {
+		result.prompts = worker.buildPrompts(tool, item.operation)
+	}
+	if c.options.EmitEvents {
+		if result.events, err = worker.callbackEvents(tool, item.operation); err != nil {
+			return operationResult{err: fmt.Errorf("failed to convert operation %s %s: %w", item.method, item.path, err)}
+		}
+	}
+	result.warnings = worker.warnings
+	return result
+}
@@ -0,0 +1,111 @@
+package converter
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+// largeSpec generates a specification with the given number of paths, each with a GET and a POST operation
+func largeSpec(paths int) []byte {
+	spec := map[string]any{
+		"openapi": "3.0.0",
+		"info":    map[string]any{"title": "Large API", "version": "1.0.0"},
+		"servers": []any{map[string]any{"url": "https://api.example.com"}},
+	}
+	item := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"name":   map[string]any{"type": "string", "description": "Name of the item"},
+			"count":  map[string]any{"type": "integer"},
+			"labels": map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+		},
+	}
+	pathItems := make(map[string]any, paths)
+	for i := range paths {
+		pathItems[fmt.Sprintf("/resources%d/{id}", i)] = map[string]any{
+			"get": map[string]any{
+				"operationId": fmt.Sprintf("getResource%d", i),
+				"summary":     "Get a <b>resource</b>. Returns the resource with the given ID.",
+				"parameters": []any{
+					map[string]any{"name": "id", "in": "path", "required": true, "schema": map[string]any{"type": "string"}},
+					map[string]any{"name": "fields", "in": "query", "description": "Fields to return", "schema": map[string]any{"type": "string"}},
+				},
+				"responses": map[string]any{
+					"200": map[string]any{
+						"description": "The resource",
+						"content":     map[string]any{"application/json": map[string]any{"schema": item}},
+					},
+				},
+			},
+			"post": map[string]any{
+				"operationId": fmt.Sprintf("updateResource%d", i),
+				"summary":     "Update a resource",
+				"parameters": []any{
+					map[string]any{"name": "id", "in": "path", "required": true, "schema": map[string]any{"type": "string"}},
+				},
+				"requestBody": map[string]any{
+					"content": map[string]any{"application/json": map[string]any{"schema": item}},
+				},
+				"responses": map[string]any{
+					"200": map[string]any{"description": "Updated"},
+				},
+			},
+		}
+	}
+	spec["paths"] = pathItems
+
+	data, err := json.Marshal(spec)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+func TestConcurrencyIsDeterministic(t *testing.T) {
+	p := parser.NewParser()
+	if !assert.NoError(t, p.Parse(largeSpec(50))) {
+		return
+	}
+
+	var configs []*models.MCPConfig
+	var warnings [][]models.Warning
+	for _, concurrency := range []int{1, 4, 16} {
+		c := NewConverter(p, models.ConvertOptions{Concurrency: concurrency, EmitPrompts: true})
+		config, err := c.Convert()
+		if !assert.NoError(t, err) {
+			return
+		}
+		configs = append(configs, config)
+		warnings = append(warnings, c.Warnings())
+	}
+
+	assert.Len(t, configs[0].Tools, 100)
+	assert.NotEmpty(t, warnings[0])
+	for i := 1; i < len(configs); i++ {
+		assert.Equal(t, configs[0], configs[i])
+		assert.Equal(t, warnings[0], warnings[i])
+	}
+}
+
+func BenchmarkConvert(b *testing.B) {
+	p := parser.NewParser()
+	if err := p.Parse(largeSpec(500)); err != nil {
+		b.Fatal(err)
+	}
+
+	for _, concurrency := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("concurrency=%d", concurrency), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				c := NewConverter(p, models.ConvertOptions{Concurrency: concurrency})
+				if _, err := c.Convert(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package converter
+
+import (
+	"slices"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+)
+
+// applyPassthrough sets passthrough on the security requirements of the tools selected by a
+// passthrough rule, by scheme ID or by a tag of their operation
+func (c *Converter) applyPassthrough(tools []models.Tool, rule models.Passthrough) {
+	if len(rule.Schemes) == 0 && len(rule.Tags) == 0 {
+		return
+	}
+	for i := range tools {
+		tagged := slices.ContainsFunc(c.operationTags[tools[i].Name], func(tag string) bool {
+			return slices.Contains(rule.Tags, tag)
+		})
+		tools[i].Security = passthroughSecurity(tools[i].Security, rule, tagged)
+		tools[i].RequestTemplate.Security = passthroughSecurity(tools[i].RequestTemplate.Security, rule, tagged)
+	}
+}
+
+// passthroughSecurity returns a security requirement with passthrough set if it is selected.
+// Requirements are copied, as templates share theirs between tools.
+func passthroughSecurity(security *models.ToolSecurityRequirement, rule models.Passthrough, tagged bool) *models.ToolSecurityRequirement {
+	if security == nil || security.Passthrough || !(tagged || slices.Contains(rule.Schemes, security.ID)) {
+		return security
+	}
+	selected := *security
+	selected.Passthrough = true
+	return &selected
+}
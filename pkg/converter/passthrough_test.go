@@ -0,0 +1,27 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyPassthrough(t *testing.T) {
+	// Templates share a security requirement between all tools
+	shared := &models.ToolSecurityRequirement{ID: "apiKey"}
+	tools := []models.Tool{
+		{Name: "listUsers", Security: shared, RequestTemplate: models.RequestTemplate{Security: &models.ToolSecurityRequirement{ID: "oauth", Scopes: []string{"users:read"}}}},
+		{Name: "deleteUser", Security: shared},
+		{Name: "getStatus"},
+	}
+	c := &Converter{operationTags: map[string][]string{"listUsers": {"users"}, "deleteUser": {"users", "admin"}, "getStatus": {"admin"}}}
+
+	c.applyPassthrough(tools, models.Passthrough{Schemes: []string{"oauth"}, Tags: []string{"admin"}})
+	assert.False(t, tools[0].Security.Passthrough)
+	assert.Equal(t, &models.ToolSecurityRequirement{ID: "oauth", Scopes: []string{"users:read"}, Passthrough: true}, tools[0].RequestTemplate.Security)
+	assert.True(t, tools[1].Security.Passthrough)
+	assert.False(t, shared.Passthrough)
+	assert.Nil(t, tools[2].Security)
+	assert.Nil(t, tools[2].RequestTemplate.Security)
+}
@@ -0,0 +1,38 @@
+package converter
+
+import (
+	"strings"
+)
+
+// tagPrefixedName namespaces a tool name with a tag, e.g. "users.list" for the listUsers operation
+// tagged users. Words of the tag ending the name are dropped, in singular or plural form, so the
+// namespace is not repeated; names made only of those words are kept as they are.
+func tagPrefixedName(tag, name string) string {
+	tagWords := splitName(tag)
+	if len(tagWords) == 0 {
+		return name
+	}
+	namespace := strings.Join(tagWords, "_")
+
+	nameWords := splitName(name)
+	last := tagWords[len(tagWords)-1]
+	singular := append(append([]string{}, tagWords[:len(tagWords)-1]...), strings.TrimSuffix(last, "s"))
+	plural := append(append([]string{}, tagWords[:len(tagWords)-1]...), last+"s")
+	for _, suffix := range [][]string{tagWords, singular, plural} {
+		if len(nameWords) > len(suffix) && hasWordSuffix(nameWords, suffix) {
+			return namespace + "." + joinCamel(nameWords[:len(nameWords)-len(suffix)])
+		}
+	}
+	return namespace + "." + name
+}
+
+// hasWordSuffix reports whether words end with suffix
+func hasWordSuffix(words, suffix []string) bool {
+	offset := len(words) - len(suffix)
+	for i, word := range suffix {
+		if words[offset+i] != word {
+			return false
+		}
+	}
+	return true
+}
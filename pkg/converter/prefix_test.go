@@ -0,0 +1,47 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTagPrefixedName(t *testing.T) {
+	tests := []struct {
+		tag      string
+		name     string
+		expected string
+	}{
+		{"users", "listUsers", "users.list"},
+		{"orders", "createOrder", "orders.create"},
+		{"orders", "get_order_items", "orders.get_order_items"},
+		{"orders", "cancel_order", "orders.cancel"},
+		{"Pet Store", "listPetStores", "pet_store.list"},
+		{"users", "users", "users.users"},
+		{"users", "getUserById", "users.getUserById"},
+		{"", "listUsers", "listUsers"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, tagPrefixedName(tt.tag, tt.name), "%s %s", tt.tag, tt.name)
+	}
+}
+
+func TestPrefixByTag(t *testing.T) {
+	p := parser.NewParser()
+	if !assert.NoError(t, p.Parse([]byte(orderSpec))) {
+		return
+	}
+
+	config, err := NewConverter(p, models.ConvertOptions{PrefixByTag: true, ToolNamePrefix: "acme_"}).Convert()
+	if !assert.NoError(t, err) {
+		return
+	}
+	names := make([]string, 0, len(config.Tools))
+	for _, tool := range config.Tools {
+		names = append(names, tool.Name)
+	}
+	assert.Equal(t, []string{"acme_admin.getSettings", "acme_health", "acme_reports.listAudit", "acme_users.get", "acme_users.update"}, names)
+}
@@ -0,0 +1,127 @@
+package converter
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+)
+
+// requestExample is a named set of example arguments for a tool
+type requestExample struct {
+	name    string
+	summary string
+	args    map[string]any
+}
+
+// buildPrompts creates a prompt for each request example of an operation.
+// Operations without examples produce no prompts.
+func (c *Converter) buildPrompts(tool *models.Tool, operation *openapi3.Operation) []models.Prompt {
+	var prompts []models.Prompt
+	for _, example := range requestExamples(operation) {
+		name := tool.Name + "_example"
+		if example.name != "" {
+			name = tool.Name + "_" + example.name + "_example"
+		}
+
+		description := fmt.Sprintf("Example invocation of the %s tool", tool.Name)
+		if example.summary != "" {
+			description += ": " + example.summary
+		}
+
+		args, err := json.MarshalIndent(flattenExample(example.args, tool.Args), "", "  ")
+		if err != nil {
+			continue
+		}
+		var content strings.Builder
+		if summary := firstSentence(tool.Description); summary != "" {
+			content.WriteString(summary)
+			content.WriteString("\n\n")
+		}
+		fmt.Fprintf(&content, "Call the %s tool with the following arguments:\n\n```json\n%s\n```", tool.Name, args)
+
+		prompts = append(prompts, models.Prompt{
+			Name:        name,
+			Description: description,
+			Messages:    []models.PromptMessage{{Role: "user", Content: content.String()}},
+		})
+	}
+	return prompts
+}
+
+// requestExamples collects example arguments from the parameter and request body examples of an operation.
+// Each named request body example produces its own set of arguments.
+func requestExamples(operation *openapi3.Operation) []requestExample {
+	paramArgs := make(map[string]any)
+	for _, paramRef := range operation.Parameters {
+		if paramRef.Value == nil {
+			continue
+		}
+		param := paramRef.Value
+		if param.Example != nil {
+			paramArgs[param.Name] = param.Example
+		} else if param.Schema != nil && param.Schema.Value != nil && param.Schema.Value.Example != nil {
+			paramArgs[param.Name] = param.Schema.Value.Example
+		}
+	}
+
+	var bodyExamples []requestExample
+	if operation.RequestBody != nil && operation.RequestBody.Value != nil {
+		content := operation.RequestBody.Value.Content
+		if mediaType := content[selectRequestContentType(content)]; mediaType != nil {
+			switch {
+			case len(mediaType.Examples) > 0:
+				for name, exampleRef := range mediaType.Examples {
+					if exampleRef == nil || exampleRef.Value == nil {
+						continue
+					}
+					if body, ok := exampleRef.Value.Value.(map[string]any); ok {
+						bodyExamples = append(bodyExamples, requestExample{name: name, summary: exampleRef.Value.Summary, args: body})
+					}
+				}
+				sort.Slice(bodyExamples, func(i, j int) bool {
+					return bodyExamples[i].name < bodyExamples[j].name
+				})
+			case mediaType.Example != nil:
+				if body, ok := mediaType.Example.(map[string]any); ok {
+					bodyExamples = append(bodyExamples, requestExample{args: body})
+				}
+			case mediaType.Schema != nil && mediaType.Schema.Value != nil && mediaType.Schema.Value.Example != nil:
+				if body, ok := mediaType.Schema.Value.Example.(map[string]any); ok {
+					bodyExamples = append(bodyExamples, requestExample{args: body})
+				}
+			}
+		}
+	}
+
+	if len(bodyExamples) == 0 {
+		if len(paramArgs) == 0 {
+			return nil
+		}
+		return []requestExample{{args: paramArgs}}
+	}
+
+	// Body properties are converted to top-level arguments, next to the parameters
+	for i := range bodyExamples {
+		args := make(map[string]any, len(paramArgs)+len(bodyExamples[i].args))
+		for k, v := range paramArgs {
+			args[k] = v
+		}
+		for k, v := range bodyExamples[i].args {
+			args[k] = v
+		}
+		bodyExamples[i].args = args
+	}
+	return bodyExamples
+}
+
+// sortPrompts sorts prompts by name
+func sortPrompts(prompts []models.Prompt) {
+	sort.Slice(prompts, func(i, j int) bool {
+		return prompts[i].Name < prompts[j].Name
+	})
+}
@@ -0,0 +1,50 @@
+package converter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// rateLimitExtensionPrefix starts the extensions documenting the rate limit of an operation, e.g.
+//
+//	x-ratelimit-limit: 100
+//	x-ratelimit-window: 1m
+const rateLimitExtensionPrefix = "x-ratelimit-"
+
+// AnnotationRateLimit is the tool annotation holding the rate limit of the upstream API
+const AnnotationRateLimit = "rateLimit"
+
+// rateLimit collects the x-ratelimit-* extensions of an operation keyed by their suffix in
+// lowerCamelCase, e.g. x-ratelimit-requests-per-minute as requestsPerMinute. It returns nil if there are none.
+func rateLimit(operation *openapi3.Operation) map[string]any {
+	var limit map[string]any
+	for name, value := range operation.Extensions {
+		suffix, ok := strings.CutPrefix(strings.ToLower(name), rateLimitExtensionPrefix)
+		if !ok || suffix == "" {
+			continue
+		}
+		if limit == nil {
+			limit = make(map[string]any)
+		}
+		limit[joinCamel(splitName(suffix))] = value
+	}
+	return limit
+}
+
+// rateLimitDescription describes a rate limit for tool descriptions, e.g. "Rate limit: limit 100, window 1m."
+func rateLimitDescription(limit map[string]any) string {
+	keys := make([]string, 0, len(limit))
+	for key := range limit {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fields := make([]string, 0, len(keys))
+	for _, key := range keys {
+		fields = append(fields, fmt.Sprintf("%s %v", strings.Join(splitName(key), " "), limit[key]))
+	}
+	return "Rate limit: " + strings.Join(fields, ", ") + "."
+}
@@ -0,0 +1,23 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimit(t *testing.T) {
+	operation := &openapi3.Operation{Extensions: map[string]any{
+		"x-ratelimit-limit":       float64(100),
+		"X-RateLimit-Window":      "1m",
+		"x-ratelimit-":            "ignored",
+		"x-mcp-cache":             map[string]any{"ttl": "5m"},
+		"x-ratelimit-retry-after": "60s",
+	}}
+
+	limit := rateLimit(operation)
+	assert.Equal(t, map[string]any{"limit": float64(100), "window": "1m", "retryAfter": "60s"}, limit)
+	assert.Equal(t, "Rate limit: limit 100, retry after 60s, window 1m.", rateLimitDescription(limit))
+	assert.Nil(t, rateLimit(&openapi3.Operation{}))
+}
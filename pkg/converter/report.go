@@ -0,0 +1,21 @@
+package converter
+
+import (
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+)
+
+// ToolSources returns the operation of each tool converted by the last call to Convert, in the
+// order of the tools. Tools added by templates have no operation.
+func (c *Converter) ToolSources() []models.ToolSource {
+	return c.report
+}
+
+// toolSources lists the operations of tools
+func (c *Converter) toolSources(tools []models.Tool) []models.ToolSource {
+	sources := make([]models.ToolSource, len(tools))
+	for i, tool := range tools {
+		sources[i] = c.sources[tool.Name]
+		sources[i].Tool = tool.Name
+	}
+	return sources
+}
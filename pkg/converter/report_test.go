@@ -0,0 +1,36 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToolSources(t *testing.T) {
+	p := parser.NewParser()
+	if !assert.NoError(t, p.ParseFile("../../test/petstore.json")) {
+		return
+	}
+
+	c := NewConverter(p, models.ConvertOptions{Filter: models.Filter{ExcludeOperations: []string{"createPets"}}})
+	c.AddTransformer(ToolTransformerFunc(func(tool *models.Tool) error {
+		if tool.Name == "listPets" {
+			tool.Name = "zz_listPets"
+		}
+		return nil
+	}))
+	_, report, err := c.ConvertWithReport()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	expected := []models.ToolSource{
+		{Tool: "showPetById", Method: "get", Path: "/pets/{petId}", OperationID: "showPetById"},
+		{Tool: "zz_listPets", Method: "get", Path: "/pets", OperationID: "listPets"},
+	}
+	assert.Equal(t, expected, report.Tools)
+	assert.Equal(t, expected, c.ToolSources())
+	assert.Len(t, report.Skipped, 1)
+}
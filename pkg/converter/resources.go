@@ -0,0 +1,58 @@
+package converter
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+)
+
+// isResourceOperation checks if an operation is a GET without parameters or request body,
+// which can be exposed as a static resource. Resources carry no credentials, so operations
+// with security requirements, their own or those of the document, stay tools.
+func isResourceOperation(path, method string, pathItem *openapi3.PathItem, operation *openapi3.Operation, documentSecurity openapi3.SecurityRequirements) bool {
+	security := documentSecurity
+	if operation.Security != nil {
+		security = *operation.Security
+	}
+	return method == "get" &&
+		len(pathItem.Parameters) == 0 &&
+		len(operation.Parameters) == 0 &&
+		operation.RequestBody == nil &&
+		len(security) == 0 &&
+		!strings.Contains(path, "{")
+}
+
+// convertResource converts a parameterless GET operation to an MCP resource
+func (c *Converter) convertResource(path, method, baseURL string, operation *openapi3.Operation) models.Resource {
+	return models.Resource{
+		URI:         joinURL(baseURL, path),
+		Name:        c.parser.GetOperationID(path, method, operation),
+		Description: c.toolDescription(operation),
+		MimeType:    responseContentType(operation),
+	}
+}
+
+// responseContentType returns the content type of the first success response, preferring JSON
+func responseContentType(operation *openapi3.Operation) string {
+	codes := make([]string, 0, len(operation.Responses))
+	for code, responseRef := range operation.Responses {
+		if strings.HasPrefix(code, "2") && responseRef != nil && responseRef.Value != nil {
+			codes = append(codes, code)
+		}
+	}
+	if len(codes) == 0 {
+		return ""
+	}
+	sort.Strings(codes)
+	return selectRequestContentType(operation.Responses[codes[0]].Value.Content)
+}
+
+// sortResources sorts resources by URI
+func sortResources(resources []models.Resource) {
+	sort.Slice(resources, func(i, j int) bool {
+		return resources[i].URI < resources[j].URI
+	})
+}
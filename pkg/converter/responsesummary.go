@@ -0,0 +1,61 @@
+package converter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// defaultResponseMaxDepth is the number of nesting levels of the response description
+const defaultResponseMaxDepth = 10
+
+// responseMaxDepth returns the number of nesting levels described in response descriptions
+func (c *Converter) responseMaxDepth() int {
+	if c.options.ResponseMaxDepth > 0 {
+		return c.options.ResponseMaxDepth
+	}
+	return defaultResponseMaxDepth
+}
+
+// limitFields keeps the first ResponseMaxFields names of a level of the response description,
+// returning how many were left out
+func (c *Converter) limitFields(names []string) ([]string, int) {
+	if limit := c.options.ResponseMaxFields; limit > 0 && len(names) > limit {
+		return names[:limit], len(names) - limit
+	}
+	return names, 0
+}
+
+// writeMoreFields notes the number of fields left out of a level of the response description
+func writeMoreFields(prependBody *strings.Builder, depth, omitted int) {
+	if omitted == 0 {
+		return
+	}
+	fields := "fields"
+	if omitted == 1 {
+		fields = "field"
+	}
+	fmt.Fprintf(prependBody, "%s- … and %d more %s\n", strings.Repeat("  ", depth), omitted, fields)
+}
+
+// collapseSchema refers to the first description of a structure described again at path, when
+// the response description is summarized. It reports whether the structure was collapsed.
+func (c *Converter) collapseSchema(prependBody *strings.Builder, schema *openapi3.Schema, path string, depth int) bool {
+	if c.options.ResponseMaxFields <= 0 || c.describedSchemas == nil {
+		return false
+	}
+	structure := schema
+	if schema.Type == "array" && schema.Items != nil && schema.Items.Value != nil {
+		structure, path = schema.Items.Value, path+"[]"
+	}
+	if len(structure.Properties) == 0 {
+		return false
+	}
+	if first, ok := c.describedSchemas[structure]; ok {
+		fmt.Fprintf(prependBody, "%s- Same fields as **%s**\n", strings.Repeat("  ", depth), first)
+		return true
+	}
+	c.describedSchemas[structure] = path
+	return false
+}
@@ -0,0 +1,207 @@
+package converter
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+)
+
+// shareSchemas moves the property blocks defined by more than one arg to config.Schemas,
+// replacing them with a ref. Nested blocks are shared first, so shared schemas can reference each other.
+// Schemas are named after the component schema with the same signature in components, if any.
+func shareSchemas(config *models.MCPConfig, components map[string]string) {
+	counts := make(map[string]int)
+	var count func(arg models.Arg)
+	count = func(arg models.Arg) {
+		if key := propertiesKey(arg.Properties); key != "" {
+			counts[key]++
+		}
+		forEachNestedArg(arg, count)
+	}
+	for _, tool := range config.Tools {
+		for _, arg := range tool.Args {
+			count(arg)
+		}
+	}
+
+	names := make(map[string]string)
+	used := make(map[string]bool)
+	var rewrite func(arg models.Arg, name string) models.Arg
+	rewrite = func(arg models.Arg, name string) models.Arg {
+		key := propertiesKey(arg.Properties)
+		if arg.Properties != nil {
+			properties := make(map[string]models.Arg, len(arg.Properties))
+			for _, propName := range sortedKeys(arg.Properties) {
+				properties[propName] = rewrite(arg.Properties[propName], propName)
+			}
+			arg.Properties = properties
+		}
+		if arg.Items != nil {
+			items := rewrite(*arg.Items, name+"Item")
+			arg.Items = &items
+		}
+		if arg.AdditionalProperties != nil {
+			values := rewrite(*arg.AdditionalProperties, name+"Value")
+			arg.AdditionalProperties = &values
+		}
+		if key == "" || counts[key] < 2 {
+			return arg
+		}
+
+		schemaName, ok := names[key]
+		if !ok {
+			schemaName = uniqueSchemaName(schemaBaseName(arg.Properties, name, components), used)
+			names[key] = schemaName
+			if config.Schemas == nil {
+				config.Schemas = make(map[string]models.Schema)
+			}
+			config.Schemas[schemaName] = models.Schema{Properties: arg.Properties}
+		}
+		arg.Properties = nil
+		arg.Ref = schemaName
+		return arg
+	}
+
+	// Blocks are named in a fixed order, so the numbering of duplicate names is deterministic
+	for i := range config.Tools {
+		for j, arg := range config.Tools[i].Args {
+			config.Tools[i].Args[j] = rewrite(arg, arg.Name)
+		}
+	}
+}
+
+// forEachNestedArg calls fn for the properties, items and map values of an arg, properties sorted by name
+func forEachNestedArg(arg models.Arg, fn func(models.Arg)) {
+	for _, name := range sortedKeys(arg.Properties) {
+		fn(arg.Properties[name])
+	}
+	if arg.Items != nil {
+		fn(*arg.Items)
+	}
+	if arg.AdditionalProperties != nil {
+		fn(*arg.AdditionalProperties)
+	}
+}
+
+// sortedKeys returns the names of properties in order
+func sortedKeys(properties map[string]models.Arg) []string {
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// schemaNameIndex maps the signature of shared schemas to their name, for sharing them again
+// under the same names. It returns nil if there are no shared schemas.
+func schemaNameIndex(schemas map[string]models.Schema) map[string]string {
+	if len(schemas) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(schemas))
+	for name := range schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	index := make(map[string]string, len(names))
+	for _, name := range names {
+		signature := argSignature(schemas[name].Properties)
+		if _, ok := index[signature]; !ok {
+			index[signature] = name
+		}
+	}
+	return index
+}
+
+// propertiesKey identifies a property block by its content, or returns "" if there are no properties
+func propertiesKey(properties map[string]models.Arg) string {
+	if len(properties) == 0 {
+		return ""
+	}
+	// Maps are encoded with sorted keys, so identical blocks have identical keys
+	data, err := json.Marshal(properties)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// componentSignatures maps the property names and types of the object schemas in the
+// components to the first schema name having them
+func (c *Converter) componentSignatures() map[string]string {
+	components := c.parser.GetDocument().Components
+	if components == nil {
+		return nil
+	}
+	names := make([]string, 0, len(components.Schemas))
+	for name := range components.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	signatures := make(map[string]string)
+	for _, name := range names {
+		ref := components.Schemas[name]
+		if ref == nil || ref.Value == nil || len(ref.Value.Properties) == 0 {
+			continue
+		}
+		signature := schemaSignature(ref.Value.Properties)
+		if _, ok := signatures[signature]; !ok {
+			signatures[signature] = name
+		}
+	}
+	return signatures
+}
+
+// schemaSignature lists the sorted names and types of schema properties
+func schemaSignature(properties openapi3.Schemas) string {
+	fields := make([]string, 0, len(properties))
+	for name, ref := range properties {
+		typ := ""
+		if ref != nil && ref.Value != nil {
+			typ = ref.Value.Type
+		}
+		fields = append(fields, name+":"+typ)
+	}
+	sort.Strings(fields)
+	return strings.Join(fields, ",")
+}
+
+// argSignature lists the sorted names and types of arg properties, like schemaSignature
+func argSignature(properties map[string]models.Arg) string {
+	fields := make([]string, 0, len(properties))
+	for name, property := range properties {
+		fields = append(fields, name+":"+property.Type)
+	}
+	sort.Strings(fields)
+	return strings.Join(fields, ",")
+}
+
+// schemaBaseName names a shared schema after the component schema with the same properties,
+// or after the arg defining it
+func schemaBaseName(properties map[string]models.Arg, argName string, components map[string]string) string {
+	if name, ok := components[argSignature(properties)]; ok {
+		return name
+	}
+	if argName == "" {
+		return "Schema"
+	}
+	return strings.ToUpper(argName[:1]) + argName[1:]
+}
+
+// uniqueSchemaName appends a number to a schema name already used
+func uniqueSchemaName(name string, used map[string]bool) string {
+	unique := name
+	for i := 2; used[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	used[unique] = true
+	return unique
+}
@@ -0,0 +1,80 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func convertSharedSchemasSpec(t *testing.T, shared bool) *models.MCPConfig {
+	p := parser.NewParser()
+	if !assert.NoError(t, p.ParseFile("../../test/shared-schemas.json")) {
+		return nil
+	}
+	config, err := NewConverter(p, models.ConvertOptions{SharedSchemas: shared}).Convert()
+	assert.NoError(t, err)
+	return config
+}
+
+func TestInlineSchemas(t *testing.T) {
+	shared := convertSharedSchemasSpec(t, true)
+	inlined := convertSharedSchemasSpec(t, false)
+	if shared == nil || inlined == nil {
+		return
+	}
+
+	assert.Len(t, shared.Schemas, 2)
+	assert.Equal(t, inlined, shared.InlineSchemas())
+	// The shared configuration is left unchanged
+	assert.Len(t, shared.Schemas, 2)
+	assert.Equal(t, "Address", shared.Tools[0].Args[0].Ref)
+}
+
+func TestShareSchemasNaming(t *testing.T) {
+	address := map[string]models.Arg{
+		"city": {Name: "city", Type: "string"},
+	}
+	point := map[string]models.Arg{
+		"x": {Name: "x", Type: "number"},
+	}
+	config := &models.MCPConfig{
+		Tools: []models.Tool{
+			{Name: "a", Args: []models.Arg{
+				{Name: "home", Type: "object", Properties: address},
+				{Name: "origin", Type: "object", Properties: point},
+				{Name: "single", Type: "object", Properties: map[string]models.Arg{"y": {Name: "y", Type: "number"}}},
+			}},
+			{Name: "b", Args: []models.Arg{
+				{Name: "work", Type: "object", Properties: address},
+				{Name: "target", Type: "object", Properties: point},
+			}},
+		},
+	}
+
+	shareSchemas(config, map[string]string{"city:string": "Address"})
+
+	assert.Equal(t, map[string]models.Schema{
+		"Address": {Properties: address},
+		"Origin":  {Properties: point},
+	}, config.Schemas)
+	assert.Equal(t, "Address", config.Tools[1].Args[0].Ref)
+	assert.Equal(t, "Origin", config.Tools[1].Args[1].Ref)
+	assert.Empty(t, config.Tools[0].Args[2].Ref)
+	assert.NotNil(t, config.Tools[0].Args[2].Properties)
+}
+
+func TestMergeSharedSchemas(t *testing.T) {
+	config := convertSharedSchemasSpec(t, true)
+	if config == nil {
+		return
+	}
+
+	merged, err := MergeConfigs([]MergeSource{{Name: "shared", Config: config}}, MergePolicyError)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, config.Schemas, merged.Schemas)
+	assert.Equal(t, config.Tools, merged.Tools)
+}
@@ -0,0 +1,174 @@
+package converter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+)
+
+// priorityAnnotation is the tool annotation ordering tools when a configuration is split;
+// tools with a higher priority come first
+const priorityAnnotation = "priority"
+
+// Shard is one of the configurations a configuration is split into
+type Shard struct {
+	Config *models.MCPConfig
+	Tags   []string
+}
+
+// SplitConfig splits a configuration into configurations of at most maxTools tools.
+// Tools are grouped by the tags they are mapped to in tags, and a group is only split when it
+// does not fit in a configuration by itself. Groups and tools are ordered by their priority
+// annotation, highest first, then by tag and name, so the most important tools come first.
+// Configurations are numbered after the server name; resources go to the first one, prompts
+// follow their tool, and shared schemas are shared again within each configuration.
+// When the server only allows some tools, the other tools are left out, since a configuration
+// without any of them would allow all its tools. A configuration within the limit is returned as it is.
+func SplitConfig(config *models.MCPConfig, maxTools int, tags map[string]string) []Shard {
+	if maxTools <= 0 || len(config.Tools) <= maxTools {
+		return []Shard{{Config: config, Tags: toolTags(config.Tools, tags)}}
+	}
+
+	// Group the tools by tag, ordering groups by their most important tool
+	groups := make(map[string][]models.Tool)
+	for _, tool := range config.InlineSchemas().Tools {
+		if len(config.Server.AllowTools) > 0 && !contains(config.Server.AllowTools, tool.Name) {
+			continue
+		}
+		groups[tags[tool.Name]] = append(groups[tags[tool.Name]], tool)
+	}
+	names := make([]string, 0, len(groups))
+	for name, tools := range groups {
+		sort.SliceStable(tools, func(i, j int) bool {
+			if a, b := toolPriority(tools[i]), toolPriority(tools[j]); a != b {
+				return a > b
+			}
+			return tools[i].Name < tools[j].Name
+		})
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		a, b := groups[names[i]], groups[names[j]]
+		if pa, pb := toolPriority(a[0]), toolPriority(b[0]); pa != pb {
+			return pa > pb
+		}
+		if names[i] == "" || names[j] == "" {
+			return names[i] != ""
+		}
+		return names[i] < names[j]
+	})
+
+	// Fill the configurations group by group, starting a new one when a group does not fit
+	var parts [][]models.Tool
+	for _, name := range names {
+		tools := groups[name]
+		last := len(parts) - 1
+		if last >= 0 && len(parts[last])+len(tools) <= maxTools {
+			parts[last] = append(parts[last], tools...)
+			continue
+		}
+		for len(tools) > 0 {
+			n := min(len(tools), maxTools)
+			parts = append(parts, tools[:n:n])
+			tools = tools[n:]
+		}
+	}
+
+	schemaNames := schemaNameIndex(config.Schemas)
+	shards := make([]Shard, 0, len(parts))
+	for i, tools := range parts {
+		shard := *config
+		shard.Tools = tools
+		shard.Schemas = nil
+		shard.Resources = nil
+		if i == 0 {
+			shard.Resources = config.Resources
+		}
+		shard.Prompts = shardPrompts(config.Prompts, config.Tools, tools)
+		shard.Events = shardEvents(config.Events, tools, i == 0)
+		shard.Server.Name = fmt.Sprintf("%s-%d", config.Server.Name, i+1)
+		shard.Server.AllowTools = shardAllowTools(config.Server.AllowTools, tools)
+		if schemaNames != nil {
+			shareSchemas(&shard, schemaNames)
+		}
+		shards = append(shards, Shard{Config: &shard, Tags: toolTags(tools, tags)})
+	}
+	return shards
+}
+
+// BuildShardIndex describes the configurations of a server written to the given files
+func BuildShardIndex(server string, shards []Shard, files []string, maxTools int) models.ShardIndex {
+	index := models.ShardIndex{Server: server, MaxToolsPerConfig: maxTools}
+	for i, shard := range shards {
+		entry := models.ShardEntry{File: files[i], Tags: shard.Tags, Tools: make([]string, 0, len(shard.Config.Tools))}
+		for _, tool := range shard.Config.Tools {
+			entry.Tools = append(entry.Tools, tool.Name)
+		}
+		index.Shards = append(index.Shards, entry)
+	}
+	return index
+}
+
+// toolPriority returns the priority annotation of a tool, 0 if it has none
+func toolPriority(tool models.Tool) float64 {
+	switch priority := tool.Annotations[priorityAnnotation].(type) {
+	case int:
+		return float64(priority)
+	case float64:
+		return priority
+	}
+	return 0
+}
+
+// toolTags lists the tags of tools in order of appearance, skipping untagged tools
+func toolTags(tools []models.Tool, tags map[string]string) []string {
+	var result []string
+	for _, tool := range tools {
+		if tag := tags[tool.Name]; tag != "" && !contains(result, tag) {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
+
+// shardPrompts returns the prompts of the given tools. Prompts are named after their tool with
+// an _example suffix, so a prompt belongs to the tool with the longest matching name.
+func shardPrompts(prompts []models.Prompt, allTools, tools []models.Tool) []models.Prompt {
+	var result []models.Prompt
+	for _, prompt := range prompts {
+		owner := ""
+		for _, tool := range allTools {
+			if strings.HasPrefix(prompt.Name, tool.Name+"_") && len(tool.Name) > len(owner) {
+				owner = tool.Name
+			}
+		}
+		if hasTool(tools, owner) {
+			result = append(result, prompt)
+		}
+	}
+	return result
+}
+
+// shardEvents returns the callbacks of the given tools, and the webhooks for the first configuration
+func shardEvents(events []models.Event, tools []models.Tool, first bool) []models.Event {
+	var result []models.Event
+	for _, event := range events {
+		if event.Tool == "" && first || event.Tool != "" && hasTool(tools, event.Tool) {
+			result = append(result, event)
+		}
+	}
+	return result
+}
+
+// shardAllowTools keeps the allowed tools that are part of a configuration
+func shardAllowTools(allowTools []string, tools []models.Tool) []string {
+	var result []string
+	for _, name := range allowTools {
+		if hasTool(tools, name) {
+			result = append(result, name)
+		}
+	}
+	return result
+}
@@ -0,0 +1,144 @@
+package converter
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func shardToolNames(shards []Shard) [][]string {
+	var names [][]string
+	for _, shard := range shards {
+		var tools []string
+		for _, tool := range shard.Config.Tools {
+			tools = append(tools, tool.Name)
+		}
+		names = append(names, tools)
+	}
+	return names
+}
+
+func sortedSchemaKeys(schemas map[string]models.Schema) []string {
+	var names []string
+	for name := range schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestSplitConfig(t *testing.T) {
+	tool := func(name string, priority any) models.Tool {
+		tool := models.Tool{Name: name}
+		if priority != nil {
+			tool.Annotations = map[string]any{priorityAnnotation: priority}
+		}
+		return tool
+	}
+	config := &models.MCPConfig{
+		Server: models.ServerConfig{Name: "shop"},
+		Tools: []models.Tool{
+			tool("createOrder", nil),
+			tool("getOrder", nil),
+			tool("listOrders", nil),
+			tool("getUser", nil),
+			tool("searchProducts", 10),
+			tool("ping", nil),
+		},
+		Resources: []models.Resource{{URI: "https://shop.example.com/status", Name: "status"}},
+		Prompts: []models.Prompt{
+			{Name: "getOrder_example"},
+			{Name: "getUser_example"},
+		},
+	}
+	tags := map[string]string{
+		"createOrder":    "orders",
+		"getOrder":       "orders",
+		"listOrders":     "orders",
+		"getUser":        "users",
+		"searchProducts": "products",
+	}
+
+	tests := []struct {
+		name     string
+		maxTools int
+		expected [][]string
+	}{
+		{"within the limit", 6, [][]string{{"createOrder", "getOrder", "listOrders", "getUser", "searchProducts", "ping"}}},
+		{"disabled", 0, [][]string{{"createOrder", "getOrder", "listOrders", "getUser", "searchProducts", "ping"}}},
+		{"groups kept together", 4, [][]string{{"searchProducts", "createOrder", "getOrder", "listOrders"}, {"getUser", "ping"}}},
+		{"groups split", 2, [][]string{{"searchProducts"}, {"createOrder", "getOrder"}, {"listOrders", "getUser"}, {"ping"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, shardToolNames(SplitConfig(config, tt.maxTools, tags)))
+		})
+	}
+
+	shards := SplitConfig(config, 4, tags)
+	assert.Equal(t, "shop-1", shards[0].Config.Server.Name)
+	assert.Equal(t, []string{"products", "orders"}, shards[0].Tags)
+	assert.Equal(t, config.Resources, shards[0].Config.Resources)
+	assert.Empty(t, shards[1].Config.Resources)
+	assert.Equal(t, []models.Prompt{{Name: "getOrder_example"}}, shards[0].Config.Prompts)
+	assert.Equal(t, []models.Prompt{{Name: "getUser_example"}}, shards[1].Config.Prompts)
+
+	index := BuildShardIndex("shop", shards, []string{"mcp-1.yaml", "mcp-2.yaml"}, 4)
+	assert.Equal(t, models.ShardIndex{
+		Server:            "shop",
+		MaxToolsPerConfig: 4,
+		Shards: []models.ShardEntry{
+			{File: "mcp-1.yaml", Tags: []string{"products", "orders"}, Tools: []string{"searchProducts", "createOrder", "getOrder", "listOrders"}},
+			{File: "mcp-2.yaml", Tags: []string{"users"}, Tools: []string{"getUser", "ping"}},
+		},
+	}, index)
+}
+
+func TestSplitConfigAllowTools(t *testing.T) {
+	config := &models.MCPConfig{
+		Server: models.ServerConfig{Name: "shop", AllowTools: []string{"getOrder", "ping"}},
+		Tools:  []models.Tool{{Name: "createOrder"}, {Name: "getOrder"}, {Name: "getUser"}, {Name: "ping"}},
+	}
+	tags := map[string]string{"createOrder": "orders", "getOrder": "orders", "getUser": "users"}
+
+	// Tools that are not allowed are left out rather than split into a configuration allowing all its tools
+	shards := SplitConfig(config, 1, tags)
+	assert.Equal(t, [][]string{{"getOrder"}, {"ping"}}, shardToolNames(shards))
+	assert.Equal(t, []string{"getOrder"}, shards[0].Config.Server.AllowTools)
+	assert.Equal(t, []string{"ping"}, shards[1].Config.Server.AllowTools)
+}
+
+func TestSplitConfigSharedSchemas(t *testing.T) {
+	p := parser.NewParser()
+	if !assert.NoError(t, p.ParseFile("../../test/shared-schemas.json")) {
+		return
+	}
+	c := NewConverter(p, models.ConvertOptions{SharedSchemas: true})
+	config, err := c.Convert()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	shards := SplitConfig(config, 2, c.ToolTags())
+	assert.Equal(t, [][]string{{"createCustomer", "createOrder"}, {"updateCustomer"}}, shardToolNames(shards))
+	// Schemas keep their name, and are only shared where still used more than once
+	assert.Equal(t, config.Schemas, shards[0].Config.Schemas)
+	assert.Equal(t, []string{"Address"}, sortedSchemaKeys(shards[1].Config.Schemas))
+	assert.Len(t, config.Tools, 3)
+}
+
+func TestShardEvents(t *testing.T) {
+	events := []models.Event{
+		{Name: "createSubscription_orderEvent", Kind: EventKindCallback, Tool: "createSubscription"},
+		{Name: "orderCreated", Kind: EventKindWebhook},
+	}
+	first := []models.Tool{{Name: "listOrders"}}
+	second := []models.Tool{{Name: "createSubscription"}}
+
+	assert.Equal(t, events[1:], shardEvents(events, first, true))
+	assert.Equal(t, events[:1], shardEvents(events, second, false))
+}
@@ -0,0 +1,120 @@
+package converter
+
+import (
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+)
+
+// maxSampleDepth stops filling nested objects of args referencing themselves
+const maxSampleDepth = 8
+
+// BuildSmokeTests creates a tools/call request for each tool of a configuration. Args are filled with
+// their first example, their default or their first enum value; required args without any get
+// a placeholder matching their type and format, and other args are left out.
+func BuildSmokeTests(config *models.MCPConfig) models.SmokeTests {
+	config = config.InlineSchemas()
+	tests := models.SmokeTests{Server: config.Server.Name, Tests: make([]models.ToolCall, 0, len(config.Tools))}
+	for i, tool := range config.Tools {
+		arguments := make(map[string]any)
+		for _, arg := range tool.Args {
+			if value, ok := sampleValue(arg, arg.Required, 0); ok {
+				arguments[arg.Name] = value
+			}
+		}
+		tests.Tests = append(tests.Tests, models.ToolCall{
+			JSONRPC: "2.0",
+			ID:      i + 1,
+			Method:  "tools/call",
+			Params:  models.ToolCallParams{Name: tool.Name, Arguments: arguments},
+		})
+	}
+	return tests
+}
+
+// sampleValue returns a value for an arg, or false if it has no sample and is not required
+func sampleValue(arg models.Arg, required bool, depth int) (any, bool) {
+	if len(arg.Examples) > 0 {
+		return arg.Examples[0], true
+	}
+	if arg.Default != nil {
+		return arg.Default, true
+	}
+	if len(arg.Enum) > 0 {
+		return arg.Enum[0], true
+	}
+	if !required || depth >= maxSampleDepth {
+		return nil, false
+	}
+	return placeholderValue(arg, depth), true
+}
+
+// placeholderValue makes up a value of the type and format of an arg, within its bounds
+func placeholderValue(arg models.Arg, depth int) any {
+	switch arg.Type {
+	case "integer":
+		return int(placeholderNumber(arg))
+	case "number":
+		return placeholderNumber(arg)
+	case "boolean":
+		return true
+	case "array":
+		item := models.Arg{Type: "string"}
+		if arg.Items != nil {
+			item = *arg.Items
+		}
+		count := max(int(arg.MinItems), 1)
+		values := make([]any, 0, count)
+		for range count {
+			value, _ := sampleValue(item, true, depth+1)
+			values = append(values, value)
+		}
+		return values
+	case "object":
+		object := make(map[string]any)
+		for _, name := range sortedKeys(arg.Properties) {
+			property := arg.Properties[name]
+			if value, ok := sampleValue(property, property.Required, depth+1); ok {
+				object[name] = value
+			}
+		}
+		return object
+	}
+	return placeholderString(arg)
+}
+
+// placeholderNumber returns 1, moved within the minimum and maximum of an arg
+func placeholderNumber(arg models.Arg) float64 {
+	value := 1.0
+	if arg.Minimum != nil && value < *arg.Minimum {
+		value = *arg.Minimum
+	}
+	if arg.Maximum != nil && value > *arg.Maximum {
+		value = *arg.Maximum
+	}
+	return value
+}
+
+// placeholderString returns a string of the format of an arg, within its minimum and maximum length
+func placeholderString(arg models.Arg) string {
+	var value string
+	switch arg.Format {
+	case "date":
+		value = "2024-01-01"
+	case "date-time":
+		value = "2024-01-01T00:00:00Z"
+	case "email":
+		value = "user@example.com"
+	case "uri", "url":
+		value = "https://example.com"
+	case "uuid":
+		value = "00000000-0000-0000-0000-000000000000"
+	default:
+		value = "example"
+	}
+	for uint64(len(value)) < arg.MinLength {
+		value += "x"
+	}
+	if arg.MaxLength != nil && uint64(len(value)) > *arg.MaxLength {
+		value = value[:*arg.MaxLength]
+	}
+	return value
+}
@@ -0,0 +1,82 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSampleValue(t *testing.T) {
+	minimum, maximum := 5.0, 0.5
+	maxLength := uint64(3)
+	tests := []struct {
+		name     string
+		arg      models.Arg
+		expected any
+		ok       bool
+	}{
+		{name: "example", arg: models.Arg{Type: "integer", Examples: []any{42, 7}, Default: 10}, expected: 42, ok: true},
+		{name: "default", arg: models.Arg{Type: "integer", Default: 10, Enum: []any{10, 20}}, expected: 10, ok: true},
+		{name: "enum", arg: models.Arg{Type: "string", Enum: []any{"asc", "desc"}}, expected: "asc", ok: true},
+		{name: "optional without sample", arg: models.Arg{Type: "string"}},
+		{name: "required string", arg: models.Arg{Type: "string", Required: true}, expected: "example", ok: true},
+		{name: "required date", arg: models.Arg{Type: "string", Format: "date-time", Required: true}, expected: "2024-01-01T00:00:00Z", ok: true},
+		{name: "required short string", arg: models.Arg{Type: "string", MaxLength: &maxLength, Required: true}, expected: "exa", ok: true},
+		{name: "required long string", arg: models.Arg{Type: "string", MinLength: 10, Required: true}, expected: "examplexxx", ok: true},
+		{name: "required integer", arg: models.Arg{Type: "integer", Minimum: &minimum, Required: true}, expected: 5, ok: true},
+		{name: "required number", arg: models.Arg{Type: "number", Maximum: &maximum, Required: true}, expected: 0.5, ok: true},
+		{name: "required boolean", arg: models.Arg{Type: "boolean", Required: true}, expected: true, ok: true},
+		{
+			name:     "required array",
+			arg:      models.Arg{Type: "array", MinItems: 2, Items: &models.Arg{Type: "integer"}, Required: true},
+			expected: []any{1, 1},
+			ok:       true,
+		},
+		{
+			name: "required object",
+			arg: models.Arg{Type: "object", Required: true, Properties: map[string]models.Arg{
+				"name": {Type: "string", Required: true},
+				"tag":  {Type: "string"},
+				"size": {Type: "integer", Default: 3},
+			}},
+			expected: map[string]any{"name": "example", "size": 3},
+			ok:       true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			value, ok := sampleValue(tc.arg, tc.arg.Required, 0)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.expected, value)
+		})
+	}
+}
+
+func TestBuildSmokeTests(t *testing.T) {
+	config := &models.MCPConfig{
+		Server: models.ServerConfig{Name: "petstore"},
+		Tools: []models.Tool{
+			{Name: "createPet", Args: []models.Arg{
+				{Name: "name", Type: "string", Required: true, Examples: []any{"Rex"}},
+				{Name: "owner", Type: "object", Required: true, Ref: "Owner"},
+			}},
+			{Name: "listPets", Args: []models.Arg{{Name: "limit", Type: "integer"}}},
+		},
+		Schemas: map[string]models.Schema{
+			"Owner": {Properties: map[string]models.Arg{"email": {Type: "string", Format: "email", Required: true}}},
+		},
+	}
+
+	assert.Equal(t, models.SmokeTests{
+		Server: "petstore",
+		Tests: []models.ToolCall{
+			{JSONRPC: "2.0", ID: 1, Method: "tools/call", Params: models.ToolCallParams{
+				Name:      "createPet",
+				Arguments: map[string]any{"name": "Rex", "owner": map[string]any{"email": "user@example.com"}},
+			}},
+			{JSONRPC: "2.0", ID: 2, Method: "tools/call", Params: models.ToolCallParams{Name: "listPets", Arguments: map[string]any{}}},
+		},
+	}, BuildSmokeTests(config))
+}
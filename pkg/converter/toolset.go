@@ -0,0 +1,94 @@
+package converter
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+)
+
+// BuildToolSet lists the tools of several server configurations in a tool set exposing them through one gateway.
+// Servers with the same name are numbered, e.g. "petstore-2". Tools with the same name in several servers
+// are resolved according to policy, like MergeConfigs: rename-with-prefix prefixes the later tool with
+// its server name, in its configuration too.
+func BuildToolSet(name string, configs []*models.MCPConfig, policy string) (*models.ToolSetConfig, error) {
+	switch policy {
+	case "":
+		policy = MergePolicyError
+	case MergePolicyError, MergePolicyPreferFirst, MergePolicyPreferLast, MergePolicyRenameWithPrefix:
+	default:
+		return nil, fmt.Errorf("unknown merge policy %q", policy)
+	}
+
+	toolSet := &models.ToolSetConfig{Name: name}
+	usedNames := make(map[string]bool)
+	owners := make(map[string]int) // Index of the server exposing each tool
+	for _, config := range configs {
+		config.Server.Name = uniqueServerName(config.Server.Name, usedNames)
+		serverTools := models.ServerToolConfig{ServerName: config.Server.Name, Tools: []string{}}
+		index := len(toolSet.ServerTools)
+
+		for _, tool := range config.Tools {
+			if len(config.Server.AllowTools) > 0 && !contains(config.Server.AllowTools, tool.Name) {
+				continue
+			}
+			owner, ok := owners[tool.Name]
+			if !ok {
+				owners[tool.Name] = index
+				serverTools.Tools = append(serverTools.Tools, tool.Name)
+				continue
+			}
+
+			other := toolSet.ServerTools[owner].ServerName
+			switch policy {
+			case MergePolicyError:
+				return nil, fmt.Errorf("server %s defines tool %s which conflicts with server %s", config.Server.Name, tool.Name, other)
+			case MergePolicyPreferLast:
+				toolSet.ServerTools[owner].Tools = slices.DeleteFunc(toolSet.ServerTools[owner].Tools, func(name string) bool {
+					return name == tool.Name
+				})
+				owners[tool.Name] = index
+				serverTools.Tools = append(serverTools.Tools, tool.Name)
+			case MergePolicyRenameWithPrefix:
+				renamed := sourcePrefix(config.Server.Name) + tool.Name
+				if _, ok := owners[renamed]; ok || hasTool(config.Tools, renamed) {
+					return nil, fmt.Errorf("server %s defines tool %s which still conflicts after renaming", config.Server.Name, tool.Name)
+				}
+				renameTool(config, tool.Name, renamed)
+				owners[renamed] = index
+				serverTools.Tools = append(serverTools.Tools, renamed)
+			}
+		}
+		toolSet.ServerTools = append(toolSet.ServerTools, serverTools)
+	}
+	return toolSet, nil
+}
+
+// uniqueServerName appends a number to a server name already used
+func uniqueServerName(name string, used map[string]bool) string {
+	unique := name
+	for i := 2; used[unique]; i++ {
+		unique = fmt.Sprintf("%s-%d", name, i)
+	}
+	used[unique] = true
+	return unique
+}
+
+// renameTool renames a tool of a configuration, along with the references to it
+func renameTool(config *models.MCPConfig, name, renamed string) {
+	for i := range config.Tools {
+		if config.Tools[i].Name == name {
+			config.Tools[i].Name = renamed
+		}
+	}
+	for i := range config.Server.AllowTools {
+		if config.Server.AllowTools[i] == name {
+			config.Server.AllowTools[i] = renamed
+		}
+	}
+	for i := range config.Events {
+		if config.Events[i].Tool == name {
+			config.Events[i].Tool = renamed
+		}
+	}
+}
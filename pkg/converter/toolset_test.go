@@ -0,0 +1,97 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildToolSet(t *testing.T) {
+	newConfigs := func() []*models.MCPConfig {
+		return []*models.MCPConfig{
+			{
+				Server: models.ServerConfig{Name: "users"},
+				Tools:  []models.Tool{{Name: "getUser"}, {Name: "health"}},
+			},
+			{
+				Server: models.ServerConfig{Name: "orders", AllowTools: []string{"getOrder", "health"}},
+				Tools:  []models.Tool{{Name: "deleteOrder"}, {Name: "getOrder"}, {Name: "health"}},
+				Events: []models.Event{{Name: "health_ping", Kind: EventKindCallback, Tool: "health"}},
+			},
+			{
+				Server: models.ServerConfig{Name: "users"},
+				Tools:  []models.Tool{{Name: "listUsers"}},
+			},
+		}
+	}
+
+	tests := []struct {
+		policy   string
+		expected []models.ServerToolConfig
+		wantErr  string
+	}{
+		{
+			policy:  MergePolicyError,
+			wantErr: "server orders defines tool health which conflicts with server users",
+		},
+		{
+			policy: MergePolicyPreferFirst,
+			expected: []models.ServerToolConfig{
+				{ServerName: "users", Tools: []string{"getUser", "health"}},
+				{ServerName: "orders", Tools: []string{"getOrder"}},
+				{ServerName: "users-2", Tools: []string{"listUsers"}},
+			},
+		},
+		{
+			policy: MergePolicyPreferLast,
+			expected: []models.ServerToolConfig{
+				{ServerName: "users", Tools: []string{"getUser"}},
+				{ServerName: "orders", Tools: []string{"getOrder", "health"}},
+				{ServerName: "users-2", Tools: []string{"listUsers"}},
+			},
+		},
+		{
+			policy: MergePolicyRenameWithPrefix,
+			expected: []models.ServerToolConfig{
+				{ServerName: "users", Tools: []string{"getUser", "health"}},
+				{ServerName: "orders", Tools: []string{"getOrder", "orders_health"}},
+				{ServerName: "users-2", Tools: []string{"listUsers"}},
+			},
+		},
+		{
+			policy:  "merge",
+			wantErr: `unknown merge policy "merge"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.policy, func(t *testing.T) {
+			configs := newConfigs()
+			toolSet, err := BuildToolSet("gateway", configs, tc.policy)
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, &models.ToolSetConfig{Name: "gateway", ServerTools: tc.expected}, toolSet)
+		})
+	}
+}
+
+func TestBuildToolSetRenamesReferences(t *testing.T) {
+	configs := []*models.MCPConfig{
+		{Server: models.ServerConfig{Name: "users"}, Tools: []models.Tool{{Name: "health"}}},
+		{
+			Server: models.ServerConfig{Name: "orders", AllowTools: []string{"health"}},
+			Tools:  []models.Tool{{Name: "health"}},
+			Events: []models.Event{{Name: "health_ping", Kind: EventKindCallback, Tool: "health"}},
+		},
+	}
+	_, err := BuildToolSet("gateway", configs, MergePolicyRenameWithPrefix)
+	assert.NoError(t, err)
+	assert.Equal(t, "orders_health", configs[1].Tools[0].Name)
+	assert.Equal(t, []string{"orders_health"}, configs[1].Server.AllowTools)
+	assert.Equal(t, "orders_health", configs[1].Events[0].Tool)
+	assert.Equal(t, "health", configs[0].Tools[0].Name)
+}
@@ -0,0 +1,94 @@
+package converter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+)
+
+// ToolTransformer rewrites a converted tool before it is written, e.g. to apply organization-specific
+// naming or descriptions. Transformers run after the template, in the order they were added.
+type ToolTransformer interface {
+	Transform(*models.Tool) error
+}
+
+// ToolTransformerFunc adapts a function to a ToolTransformer
+type ToolTransformerFunc func(*models.Tool) error
+
+// Transform calls f(tool)
+func (f ToolTransformerFunc) Transform(tool *models.Tool) error {
+	return f(tool)
+}
+
+// AddTransformer registers transformers applied to every tool by Convert
+func (c *Converter) AddTransformer(transformers ...ToolTransformer) {
+	c.transformers = append(c.transformers, transformers...)
+}
+
+// ScriptTransformer runs an executable for each tool, passing the tool as JSON on stdin
+// and reading the rewritten tool as JSON from stdout
+type ScriptTransformer struct {
+	Path string
+}
+
+// Transform runs the script on a tool
+func (s ScriptTransformer) Transform(tool *models.Tool) error {
+	input, err := json.Marshal(tool)
+	if err != nil {
+		return fmt.Errorf("failed to encode tool: %w", err)
+	}
+	var stdout, stderr bytes.Buffer
+	path := s.Path
+	if filepath.Base(path) == path {
+		// A bare file name refers to the current directory, not to a command in PATH
+		path = "." + string(filepath.Separator) + path
+	}
+	cmd := exec.Command(path)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return fmt.Errorf("failed to run transform script %s: %w: %s", s.Path, err, message)
+		}
+		return fmt.Errorf("failed to run transform script %s: %w", s.Path, err)
+	}
+
+	var transformed models.Tool
+	if err := json.Unmarshal(stdout.Bytes(), &transformed); err != nil {
+		return fmt.Errorf("failed to parse the output of transform script %s: %w", s.Path, err)
+	}
+	*tool = transformed
+	return nil
+}
+
+// transformTools applies the registered transformers to the tools, keeping the tags, operations
+// and operation hashes of renamed tools
+func (c *Converter) transformTools(tools []models.Tool) error {
+	if len(c.transformers) == 0 {
+		return nil
+	}
+
+	for i := range tools {
+		name := tools[i].Name
+		for _, transformer := range c.transformers {
+			if err := transformer.Transform(&tools[i]); err != nil {
+				return fmt.Errorf("failed to transform tool %s: %w", name, err)
+			}
+		}
+		if tools[i].Name != name {
+			c.tags[tools[i].Name] = c.tags[name]
+			c.sources[tools[i].Name] = c.sources[name]
+			if c.incremental {
+				c.hashes[tools[i].Name] = c.hashes[name]
+				delete(c.hashes, name)
+			}
+		}
+	}
+	return nil
+}
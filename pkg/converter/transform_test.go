@@ -0,0 +1,70 @@
+package converter
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransformers(t *testing.T) {
+	p := parser.NewParser()
+	if !assert.NoError(t, p.ParseFile("../../test/petstore.json")) {
+		return
+	}
+
+	c := NewConverter(p, models.ConvertOptions{})
+	c.AddTransformer(
+		ToolTransformerFunc(func(tool *models.Tool) error {
+			tool.Name = "acme_" + strings.ToLower(tool.Name)
+			return nil
+		}),
+		ToolTransformerFunc(func(tool *models.Tool) error {
+			tool.Description = "[ACME] " + tool.Description
+			return nil
+		}),
+	)
+	config, err := c.Convert()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var names []string
+	for _, tool := range config.Tools {
+		names = append(names, tool.Name)
+		assert.True(t, strings.HasPrefix(tool.Description, "[ACME] "))
+	}
+	assert.Equal(t, []string{"acme_createpets", "acme_listpets", "acme_showpetbyid"}, names)
+	assert.Equal(t, "pets", c.ToolTags()["acme_listpets"])
+
+	c = NewConverter(p, models.ConvertOptions{})
+	c.AddTransformer(ToolTransformerFunc(func(tool *models.Tool) error {
+		return errors.New("not allowed")
+	}))
+	_, err = c.Convert()
+	assert.EqualError(t, err, "failed to transform tool listPets: not allowed")
+}
+
+func TestScriptTransformer(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("transform scripts are shell scripts")
+	}
+	dir := t.TempDir()
+	script := filepath.Join(dir, "transform.sh")
+	assert.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\nsed 's/\"name\":\"listPets\"/\"name\":\"pets_list\"/'\n"), 0o755))
+	failing := filepath.Join(dir, "failing.sh")
+	assert.NoError(t, os.WriteFile(failing, []byte("#!/bin/sh\necho 'no tools today' >&2\nexit 1\n"), 0o755))
+
+	tool := models.Tool{Name: "listPets", Description: "List all pets", Args: []models.Arg{{Name: "limit", Type: "integer"}}}
+	assert.NoError(t, ScriptTransformer{Path: script}.Transform(&tool))
+	assert.Equal(t, models.Tool{Name: "pets_list", Description: "List all pets", Args: []models.Arg{{Name: "limit", Type: "integer"}}}, tool)
+
+	err := ScriptTransformer{Path: failing}.Transform(&tool)
+	assert.ErrorContains(t, err, "no tools today")
+}
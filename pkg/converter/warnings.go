@@ -0,0 +1,247 @@
+package converter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+)
+
+// Warning categories
+const (
+	// WarningLossySchema is reported when parts of a schema cannot be represented in the MCP configuration
+	WarningLossySchema = "lossy-schema"
+	// WarningMissingDescription is reported for tools and arguments without a description
+	WarningMissingDescription = "missing-description"
+	// WarningMissingOperationID is reported for operations whose tool name is generated
+	WarningMissingOperationID = "missing-operation-id"
+	// WarningNameCollision is reported when tools or arguments of a tool share a name
+	WarningNameCollision = "name-collision"
+	// WarningIgnoredSecurity is reported for security requirements that are not applied to tools
+	WarningIgnoredSecurity = "ignored-security"
+)
+
+// WarningCategories lists all warning categories
+var WarningCategories = []string{
+	WarningIgnoredSecurity,
+	WarningLossySchema,
+	WarningMissingDescription,
+	WarningMissingOperationID,
+	WarningNameCollision,
+}
+
+// Warning codes identify the precise problem within a category
+const (
+	CodeUnsupportedContentType = "unsupported-content-type"
+	CodeBodyWithoutProperties  = "body-without-properties"
+	CodeUnsupportedKeyword     = "unsupported-schema-keyword"
+	CodeSchemaDepthTruncated   = "schema-depth-truncated"
+	CodeMissingToolDescription = "missing-tool-description"
+	CodeMissingArgDescription  = "missing-arg-description"
+	CodeMissingOperationID     = "missing-operation-id"
+	CodeDuplicateArg           = "duplicate-arg"
+	CodeDuplicateToolName      = "duplicate-tool-name"
+	CodeAlternativeSecurity    = "alternative-security-requirement"
+	CodeCombinedSecurity       = "combined-security-schemes"
+	CodeDocumentSecurity       = "document-security-requirement"
+	CodeUnknownSecurityScheme  = "unknown-security-scheme"
+)
+
+// maxSchemaDepth is the nesting depth below which schemas are not converted, guarding against recursive schemas
+const maxSchemaDepth = 10
+
+// Warnings returns the warnings collected by the last conversion, sorted by tool and category
+func (c *Converter) Warnings() []models.Warning {
+	return c.warnings
+}
+
+// warn records a warning
+func (c *Converter) warn(category, code, tool, format string, args ...any) {
+	c.warnings = append(c.warnings, models.Warning{
+		Category: category,
+		Code:     code,
+		Tool:     tool,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// Skipped returns the operations skipped by the last conversion, sorted by path and method
+func (c *Converter) Skipped() []models.SkippedOperation {
+	return c.skipped
+}
+
+// skip records an operation that is not converted
+func (c *Converter) skip(path, method, operationID, reason string) {
+	c.skipped = append(c.skipped, models.SkippedOperation{
+		Method:      method,
+		Path:        path,
+		OperationID: operationID,
+		Reason:      reason,
+	})
+}
+
+// sortWarnings orders warnings deterministically
+func sortWarnings(warnings []models.Warning) {
+	sort.SliceStable(warnings, func(i, j int) bool {
+		if warnings[i].Tool != warnings[j].Tool {
+			return warnings[i].Tool < warnings[j].Tool
+		}
+		if warnings[i].Category != warnings[j].Category {
+			return warnings[i].Category < warnings[j].Category
+		}
+		if warnings[i].Code != warnings[j].Code {
+			return warnings[i].Code < warnings[j].Code
+		}
+		return warnings[i].Message < warnings[j].Message
+	})
+}
+
+// promotedWarnings returns an error listing the warnings that must fail the conversion
+func (c *Converter) promotedWarnings() error {
+	if len(c.options.FailOnWarnings) == 0 && !c.options.WarningsAsErrors {
+		return nil
+	}
+	var failed []string
+	for _, warning := range c.warnings {
+		if c.options.WarningsAsErrors || contains(c.options.FailOnWarnings, warning.Category) {
+			failed = append(failed, warning.String())
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d warning(s) promoted to errors:\n  %s", len(failed), strings.Join(failed, "\n  "))
+}
+
+// checkTool reports missing descriptions, argument name collisions, lossy schemas
+// and ignored security requirements of a converted tool
+func (c *Converter) checkTool(tool *models.Tool, operation *openapi3.Operation) {
+	if operation.OperationID == "" {
+		c.warn(WarningMissingOperationID, CodeMissingOperationID, tool.Name, "operation has no operationId, the tool name is generated")
+	}
+	if tool.Description == "" {
+		c.warn(WarningMissingDescription, CodeMissingToolDescription, tool.Name, "tool has no description")
+	}
+
+	seen := make(map[string]string)
+	for _, arg := range tool.Args {
+		if arg.Description == "" {
+			c.warn(WarningMissingDescription, CodeMissingArgDescription, tool.Name, "argument %q has no description", arg.Name)
+		}
+		if position, ok := seen[arg.Name]; ok {
+			c.warn(WarningNameCollision, CodeDuplicateArg, tool.Name, "argument %q is defined both in %s and %s", arg.Name, position, arg.Position)
+		}
+		seen[arg.Name] = arg.Position
+	}
+
+	c.checkSecurity(tool.Name, operation)
+
+	for _, paramRef := range operation.Parameters {
+		if paramRef.Value != nil && paramRef.Value.Schema != nil {
+			c.checkSchema(tool.Name, "parameter "+paramRef.Value.Name, paramRef.Value.Schema.Value, 0)
+		}
+	}
+
+	if operation.RequestBody == nil || operation.RequestBody.Value == nil {
+		return
+	}
+	content := operation.RequestBody.Value.Content
+	contentType := selectRequestContentType(content)
+	if contentType == "" {
+		return
+	}
+	mediaType := content[contentType]
+	if mediaType.Schema == nil || mediaType.Schema.Value == nil {
+		return
+	}
+	schema := mediaType.Schema.Value
+	switch {
+	case !isJSONContentType(contentType) && !isFormContentType(contentType) && !isXMLContentType(contentType):
+		c.warn(WarningLossySchema, CodeUnsupportedContentType, tool.Name, "request body of content type %s is not converted to arguments", contentType)
+	case schema.Type != "object" || len(schema.Properties) == 0:
+		c.warn(WarningLossySchema, CodeBodyWithoutProperties, tool.Name, "request body schema without properties is not converted to arguments")
+	default:
+		// The body schema is one level above the arguments converted from its properties
+		c.checkSchema(tool.Name, "request body", schema, -1)
+	}
+}
+
+// checkSecurity reports security requirements of an operation that the tool doesn't apply
+func (c *Converter) checkSecurity(tool string, operation *openapi3.Operation) {
+	doc := c.parser.GetDocument()
+	if operation.Security == nil {
+		if len(doc.Security) > 0 {
+			c.warn(WarningIgnoredSecurity, CodeDocumentSecurity, tool, "document-level security requirements are not applied, declare security on the operation")
+		}
+		return
+	}
+
+	requirements := *operation.Security
+	for i, requirement := range requirements {
+		names := make([]string, 0, len(requirement))
+		for name := range requirement {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			if doc.Components == nil || doc.Components.SecuritySchemes[name] == nil {
+				c.warn(WarningIgnoredSecurity, CodeUnknownSecurityScheme, tool, "security scheme %q is not defined in components", name)
+			}
+		}
+		if i == 0 && len(names) > 1 {
+			c.warn(WarningIgnoredSecurity, CodeCombinedSecurity, tool, "only security scheme %q is applied, %s ignored", names[0], strings.Join(names[1:], ", "))
+		}
+		if i > 0 && len(names) > 0 {
+			c.warn(WarningIgnoredSecurity, CodeAlternativeSecurity, tool, "alternative security requirement %s is ignored", strings.Join(names, " + "))
+		}
+	}
+}
+
+// checkSchema walks a schema looking for constructs that the argument model cannot represent
+func (c *Converter) checkSchema(tool, location string, schema *openapi3.Schema, depth int) {
+	if schema == nil {
+		return
+	}
+	if depth >= maxSchemaDepth {
+		if schema.Items != nil || len(schema.Properties) > 0 {
+			c.warn(WarningLossySchema, CodeSchemaDepthTruncated, tool, "%s is nested deeper than %d levels and is truncated", location, maxSchemaDepth)
+		}
+		return
+	}
+
+	for keyword, subschemas := range map[string]openapi3.SchemaRefs{"oneOf": schema.OneOf, "anyOf": schema.AnyOf, "allOf": schema.AllOf} {
+		if len(subschemas) > 0 {
+			c.warn(WarningLossySchema, CodeUnsupportedKeyword, tool, "%s uses %s, which is not converted", location, keyword)
+		}
+	}
+	if schema.Not != nil {
+		c.warn(WarningLossySchema, CodeUnsupportedKeyword, tool, "%s uses not, which is not converted", location)
+	}
+
+	if schema.Items != nil {
+		c.checkSchema(tool, location+"[]", schema.Items.Value, depth+1)
+	}
+	for name, propRef := range schema.Properties {
+		if propRef != nil {
+			c.checkSchema(tool, location+"."+name, propRef.Value, depth+1)
+		}
+	}
+}
+
+// checkToolNames reports tools sharing the same name
+func (c *Converter) checkToolNames(tools []models.Tool) {
+	sources := make(map[string][]string)
+	for _, tool := range tools {
+		sources[tool.Name] = append(sources[tool.Name], tool.RequestTemplate.Method+" "+tool.RequestTemplate.URL)
+	}
+	for name, operations := range sources {
+		if len(operations) > 1 {
+			sort.Strings(operations)
+			c.warn(WarningNameCollision, CodeDuplicateToolName, name, "tool name is generated by %d operations: %s", len(operations), strings.Join(operations, ", "))
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+const warningsSpec = `{
+  "openapi": "3.0.0",
+  "info": {"title": "Warnings", "version": "1.0.0"},
+  "paths": {
+    "/items/{id}": {
+      "put": {
+        "operationId": "updateItem",
+        "summary": "Update an item",
+        "parameters": [
+          {"name": "id", "in": "path", "required": true, "description": "Item ID", "schema": {"type": "string"}}
+        ],
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {
+                "type": "object",
+                "properties": {
+                  "id": {"type": "string", "description": "New item ID"},
+                  "value": {"oneOf": [{"type": "string"}, {"type": "integer"}]}
+                }
+              }
+            }
+          }
+        }
+      }
+    },
+    "/items": {
+      "get": {
+        "operationId": "updateItem"
+      }
+    }
+  }
+}`
+
+func TestWarnings(t *testing.T) {
+	p := parser.NewParser()
+	p.SetValidation(false)
+	assert.NoError(t, p.Parse([]byte(warningsSpec)))
+
+	c := NewConverter(p, models.ConvertOptions{})
+	_, err := c.Convert()
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Warning{
+		{Category: WarningLossySchema, Code: CodeUnsupportedKeyword, Tool: "updateItem", Message: "request body.value uses oneOf, which is not converted"},
+		{Category: WarningMissingDescription, Code: CodeMissingArgDescription, Tool: "updateItem", Message: `argument "value" has no description`},
+		{Category: WarningMissingDescription, Code: CodeMissingToolDescription, Tool: "updateItem", Message: "tool has no description"},
+		{Category: WarningNameCollision, Code: CodeDuplicateArg, Tool: "updateItem", Message: `argument "id" is defined both in path and body`},
+		{Category: WarningNameCollision, Code: CodeDuplicateToolName, Tool: "updateItem", Message: "tool name is generated by 2 operations: GET /items, PUT /items/{id}"},
+	}, c.Warnings())
+}
+
+const securityWarningsSpec = `{
+  "openapi": "3.0.0",
+  "info": {"title": "Security", "version": "1.0.0"},
+  "security": [{"apiKey": []}],
+  "components": {
+    "securitySchemes": {
+      "apiKey": {"type": "apiKey", "in": "header", "name": "X-API-Key"},
+      "bearer": {"type": "http", "scheme": "bearer"}
+    }
+  },
+  "paths": {
+    "/reports": {
+      "get": {
+        "summary": "List reports",
+        "description": "List reports"
+      },
+      "post": {
+        "operationId": "createReport",
+        "description": "Create a report",
+        "security": [{"bearer": [], "apiKey": []}, {"oauth": []}]
+      }
+    }
+  }
+}`
+
+func TestSecurityWarnings(t *testing.T) {
+	p := parser.NewParser()
+	assert.NoError(t, p.Parse([]byte(securityWarningsSpec)))
+
+	c := NewConverter(p, models.ConvertOptions{})
+	config, report, err := c.ConvertWithReport()
+	assert.NoError(t, err)
+	// Tools are sorted by name, so createReport follows "List reports"
+	assert.Equal(t, "apiKey", config.Tools[1].RequestTemplate.Security.ID)
+	assert.Equal(t, []models.Warning{
+		{Category: WarningIgnoredSecurity, Code: CodeDocumentSecurity, Tool: "List reports", Message: "document-level security requirements are not applied, declare security on the operation"},
+		{Category: WarningMissingOperationID, Code: CodeMissingOperationID, Tool: "List reports", Message: "operation has no operationId, the tool name is generated"},
+		{Category: WarningIgnoredSecurity, Code: CodeAlternativeSecurity, Tool: "createReport", Message: "alternative security requirement oauth is ignored"},
+		{Category: WarningIgnoredSecurity, Code: CodeCombinedSecurity, Tool: "createReport", Message: `only security scheme "apiKey" is applied, bearer ignored`},
+		{Category: WarningIgnoredSecurity, Code: CodeUnknownSecurityScheme, Tool: "createReport", Message: `security scheme "oauth" is not defined in components`},
+	}, report.Warnings)
+	assert.Empty(t, report.Skipped)
+}
+
+func TestSchemaDepthTruncated(t *testing.T) {
+	// A recursive schema would otherwise be expanded forever
+	p := parser.NewParser()
+	assert.NoError(t, p.Parse([]byte(`{
+  "openapi": "3.0.0",
+  "info": {"title": "Tree", "version": "1.0.0"},
+  "components": {
+    "schemas": {
+      "Node": {
+        "type": "object",
+        "description": "Tree node",
+        "properties": {"children": {"type": "array", "description": "Child nodes", "items": {"$ref": "#/components/schemas/Node"}}}
+      }
+    }
+  },
+  "paths": {
+    "/trees": {
+      "post": {
+        "operationId": "createTree",
+        "description": "Create a tree",
+        "requestBody": {"content": {"application/json": {"schema": {
+          "type": "object",
+          "properties": {"root": {"$ref": "#/components/schemas/Node"}}
+        }}}}
+      }
+    }
+  }
+}`)))
+
+	c := NewConverter(p, models.ConvertOptions{})
+	config, err := c.Convert()
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Warning{
+		{Category: WarningLossySchema, Code: CodeSchemaDepthTruncated, Tool: "createTree", Message: "request body.root.children[].children[].children[].children[].children[] is nested deeper than 10 levels and is truncated"},
+	}, c.Warnings())
+
+	// Each level of the tree takes two levels of nesting: the children array and its items
+	node := config.Tools[0].Args[0]
+	levels := 0
+	for node.Properties["children"].Items != nil {
+		node = *node.Properties["children"].Items
+		levels++
+	}
+	assert.Equal(t, 5, levels)
+}
+
+func TestWarningsAsErrors(t *testing.T) {
+	p := parser.NewParser()
+	assert.NoError(t, p.Parse([]byte(securityWarningsSpec)))
+
+	config, report, err := NewConverter(p, models.ConvertOptions{WarningsAsErrors: true}).ConvertWithReport()
+	assert.ErrorContains(t, err, "5 warning(s) promoted to errors")
+	assert.Nil(t, config)
+	assert.Len(t, report.Warnings, 5)
+}
+
+func TestFailOnWarnings(t *testing.T) {
+	tests := []struct {
+		name           string
+		failOnWarnings []string
+		wantErr        string
+	}{
+		{
+			name: "No promoted categories",
+		},
+		{
+			name:           "Promoted category without warnings",
+			failOnWarnings: []string{WarningLossySchema},
+		},
+		{
+			name:           "Promoted category with warnings",
+			failOnWarnings: []string{WarningMissingDescription},
+			wantErr:        "1 warning(s) promoted to errors:\n  [missing-description] getItem: tool has no description",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := parser.NewParser()
+			p.SetValidation(false)
+			assert.NoError(t, p.Parse([]byte(`{
+  "openapi": "3.0.0",
+  "info": {"title": "Warnings", "version": "1.0.0"},
+  "paths": {"/item": {"get": {"operationId": "getItem"}}}
+}`)))
+
+			config, err := NewConverter(p, models.ConvertOptions{FailOnWarnings: tc.failOnWarnings}).Convert()
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+				assert.Nil(t, config)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, config)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package converter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// maxXMLDepth bounds the nesting of generated XML bodies, guarding against recursive schemas
+const maxXMLDepth = 10
+
+// isXMLContentType checks if a content type carries an XML payload
+func isXMLContentType(contentType string) bool {
+	mediaType := strings.TrimSpace(strings.Split(contentType, ";")[0])
+	return mediaType == "application/xml" || mediaType == "text/xml" || strings.HasSuffix(mediaType, "+xml")
+}
+
+// xmlBodyTemplate generates a request body template rendering the body args as XML.
+// Element and attribute names, namespaces and array wrapping follow the xml objects of the schema;
+// optional values are only rendered when the arg is set. Values are escaped with the html
+// function of the template, which escapes the XML special characters too.
+func xmlBodyTemplate(schemaRef *openapi3.SchemaRef) string {
+	root := "request"
+	if schemaRef.Ref != "" {
+		root = schemaRef.Ref[strings.LastIndex(schemaRef.Ref, "/")+1:]
+	}
+
+	var b strings.Builder
+	writeXMLElement(&b, root, schemaRef.Value, ".args", 0, true)
+	return b.String()
+}
+
+// writeXMLElement writes the template of the element of a property, holding the value of expr
+func writeXMLElement(b *strings.Builder, propName string, schema *openapi3.Schema, expr string, depth int, required bool) {
+	name := xmlName(schema, propName)
+	indent := strings.Repeat("  ", depth)
+	if !required {
+		fmt.Fprintf(b, "%s{{- if %s}}\n", indent, expr)
+	}
+
+	switch {
+	case schema.Type == "array" && schema.Items != nil && schema.Items.Value != nil:
+		// Items are named after the property unless they have their own xml name
+		items := schema.Items.Value
+		itemDepth := depth
+		if schema.XML != nil && schema.XML.Wrapped {
+			fmt.Fprintf(b, "%s<%s%s>\n", indent, name, xmlNamespace(schema))
+			itemDepth++
+		}
+		fmt.Fprintf(b, "%s{{- range %s}}\n", strings.Repeat("  ", itemDepth), expr)
+		writeXMLElement(b, propName, items, ".", itemDepth, true)
+		fmt.Fprintf(b, "%s{{- end}}\n", strings.Repeat("  ", itemDepth))
+		if schema.XML != nil && schema.XML.Wrapped {
+			fmt.Fprintf(b, "%s</%s>\n", indent, name)
+		}
+	case len(schema.Properties) > 0 && depth < maxXMLDepth:
+		propNames := make([]string, 0, len(schema.Properties))
+		for propName := range schema.Properties {
+			propNames = append(propNames, propName)
+		}
+		sort.Strings(propNames)
+
+		// Attributes go into the start tag, other properties become child elements
+		var attributes strings.Builder
+		var children []string
+		for _, propName := range propNames {
+			prop := schema.Properties[propName].Value
+			if prop == nil {
+				continue
+			}
+			if prop.XML == nil || !prop.XML.Attribute {
+				children = append(children, propName)
+				continue
+			}
+			propExpr := fieldExpr(expr, propName)
+			attribute := fmt.Sprintf(` %s="{{%s | html}}"`, xmlName(prop, propName), propExpr)
+			if !contains(schema.Required, propName) {
+				attribute = fmt.Sprintf("{{if %s}}%s{{end}}", propExpr, attribute)
+			}
+			attributes.WriteString(attribute)
+		}
+
+		if len(children) == 0 {
+			fmt.Fprintf(b, "%s<%s%s%s/>\n", indent, name, xmlNamespace(schema), attributes.String())
+			break
+		}
+		fmt.Fprintf(b, "%s<%s%s%s>\n", indent, name, xmlNamespace(schema), attributes.String())
+		for _, propName := range children {
+			prop := schema.Properties[propName].Value
+			writeXMLElement(b, propName, prop, fieldExpr(expr, propName), depth+1, contains(schema.Required, propName))
+		}
+		fmt.Fprintf(b, "%s</%s>\n", indent, name)
+	default:
+		fmt.Fprintf(b, "%s<%s%s>{{%s | html}}</%s>\n", indent, name, xmlNamespace(schema), expr, name)
+	}
+
+	if !required {
+		fmt.Fprintf(b, "%s{{- end}}\n", indent)
+	}
+}
+
+// xmlName returns the element or attribute name of a schema, with its namespace prefix
+func xmlName(schema *openapi3.Schema, name string) string {
+	if schema.XML == nil {
+		return name
+	}
+	if schema.XML.Name != "" {
+		name = schema.XML.Name
+	}
+	if schema.XML.Prefix != "" {
+		name = schema.XML.Prefix + ":" + name
+	}
+	return name
+}
+
+// xmlNamespace returns the namespace declaration of a schema, if any
+func xmlNamespace(schema *openapi3.Schema) string {
+	if schema.XML == nil || schema.XML.Namespace == "" {
+		return ""
+	}
+	if schema.XML.Prefix != "" {
+		return fmt.Sprintf(` xmlns:%s="%s"`, schema.XML.Prefix, schema.XML.Namespace)
+	}
+	return fmt.Sprintf(` xmlns="%s"`, schema.XML.Namespace)
+}
+
+// fieldExpr returns the template expression of a field of the value of expr
+func fieldExpr(expr, name string) string {
+	for i, r := range name {
+		if r != '_' && !unicode.IsLetter(r) && (i == 0 || !unicode.IsDigit(r)) {
+			if expr == "." {
+				return fmt.Sprintf("(index . %q)", name)
+			}
+			return fmt.Sprintf("(index %s %q)", expr, name)
+		}
+	}
+	if expr == "." {
+		return "." + name
+	}
+	return expr + "." + name
+}
@@ -0,0 +1,103 @@
+package converter
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsXMLContentType(t *testing.T) {
+	assert.True(t, isXMLContentType("application/xml"))
+	assert.True(t, isXMLContentType("text/xml; charset=utf-8"))
+	assert.True(t, isXMLContentType("application/atom+xml"))
+	assert.False(t, isXMLContentType("application/json"))
+	assert.False(t, isXMLContentType("application/xml-dtd"))
+}
+
+func TestXMLBodyTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		schema   *openapi3.SchemaRef
+		expected string
+	}{
+		{
+			name: "Unnamed root",
+			schema: &openapi3.SchemaRef{Value: &openapi3.Schema{
+				Type:     "object",
+				Required: []string{"query"},
+				Properties: openapi3.Schemas{
+					"query": {Value: &openapi3.Schema{Type: "string"}},
+				},
+			}},
+			expected: "<request>\n  <query>{{.args.query | html}}</query>\n</request>\n",
+		},
+		{
+			name: "Unwrapped array named after the property",
+			schema: &openapi3.SchemaRef{Ref: "#/components/schemas/Batch", Value: &openapi3.Schema{
+				Type:     "object",
+				Required: []string{"line-items"},
+				Properties: openapi3.Schemas{
+					"line-items": {Value: &openapi3.Schema{
+						Type: "array",
+						Items: &openapi3.SchemaRef{Value: &openapi3.Schema{
+							Type:     "object",
+							Required: []string{"sku"},
+							Properties: openapi3.Schemas{
+								"sku": {Value: &openapi3.Schema{Type: "string", XML: &openapi3.XML{Attribute: true}}},
+							},
+						}},
+					}},
+				},
+			}},
+			expected: "<Batch>\n" +
+				"  {{- range (index .args \"line-items\")}}\n" +
+				"  <line-items sku=\"{{.sku | html}}\"/>\n" +
+				"  {{- end}}\n" +
+				"</Batch>\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, xmlBodyTemplate(tc.schema))
+		})
+	}
+}
+
+func TestXMLBodyTemplateEscapesValues(t *testing.T) {
+	schema := &openapi3.SchemaRef{Value: &openapi3.Schema{
+		Type:     "object",
+		Required: []string{"note", "lang"},
+		Properties: openapi3.Schemas{
+			"note": {Value: &openapi3.Schema{Type: "string"}},
+			"lang": {Value: &openapi3.Schema{Type: "string", XML: &openapi3.XML{Attribute: true}}},
+		},
+	}}
+	tmpl, err := template.New("body").Parse(xmlBodyTemplate(schema))
+	if !assert.NoError(t, err) {
+		return
+	}
+	var body strings.Builder
+	args := map[string]any{"note": "</note><admin>true</admin>", "lang": `en" admin="true`}
+	if assert.NoError(t, tmpl.Execute(&body, map[string]any{"args": args})) {
+		assert.Equal(t, "<request lang=\"en&#34; admin=&#34;true\">\n  <note>&lt;/note&gt;&lt;admin&gt;true&lt;/admin&gt;</note>\n</request>\n", body.String())
+	}
+}
+
+func TestXMLResponseNoteKeepsSummary(t *testing.T) {
+	p := parser.NewParser()
+	if !assert.NoError(t, p.ParseFile("../../test/xml-body.json")) {
+		return
+	}
+	tool, err := NewConverter(p, models.ConvertOptions{ResponseMaxFields: 4}).ConvertOperation("/items/{id}", "get", p.GetPaths()["/items/{id}"].Get)
+	if assert.NoError(t, err) {
+		prependBody := tool.ResponseTemplate.PrependBody
+		assert.True(t, strings.HasPrefix(prependBody, "The response is an XML document (Content-Type: application/xml).\n\n"))
+		assert.Contains(t, prependBody, "## Response Structure")
+	}
+}
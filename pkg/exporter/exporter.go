@@ -0,0 +1,303 @@
+// Package exporter reconstructs OpenAPI documents from MCP configurations,
+// so that hand-written configurations can be used with standard API tooling.
+package exporter
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+)
+
+// Extensions read back by the converter, so that exported documents convert to the same tools
+const (
+	annotationsExtension       = "x-mcp-annotations"
+	cacheExtension             = "x-mcp-cache"
+	defaultCredentialExtension = "x-mcp-default-credential"
+)
+
+// argReference matches {{.args.name}} references in URL templates
+var argReference = regexp.MustCompile(`{{\s*\.args\.([A-Za-z0-9_-]+)\s*}}`)
+
+// Export builds a minimal OpenAPI 3.0 document from the tools of an MCP configuration.
+// Each tool becomes an operation whose ID is the tool name; args become parameters or
+// request body properties depending on their position, and security schemes, annotations
+// and cache policies are kept as components and extensions.
+func Export(config *models.MCPConfig) (*openapi3.T, error) {
+	// Args referencing shared schemas keep their own description, which a $ref cannot have in OpenAPI 3.0
+	config = config.InlineSchemas()
+	title := config.Server.Name
+	if title == "" {
+		title = "MCP Server"
+	}
+	doc := &openapi3.T{
+		OpenAPI: "3.0.0",
+		Info:    &openapi3.Info{Title: title, Version: "1.0.0"},
+		Paths:   openapi3.Paths{},
+	}
+	baseURL := config.Server.BaseURL
+	if baseURL != "" {
+		doc.Servers = openapi3.Servers{{URL: baseURL}}
+	}
+
+	for _, scheme := range config.Server.SecuritySchemes {
+		if doc.Components == nil {
+			doc.Components = &openapi3.Components{SecuritySchemes: openapi3.SecuritySchemes{}}
+		}
+		doc.Components.SecuritySchemes[scheme.ID] = &openapi3.SecuritySchemeRef{Value: exportSecurityScheme(scheme)}
+	}
+
+	for _, tool := range config.Tools {
+		server, path, query := splitURL(tool.RequestTemplate.URL)
+		method := strings.ToUpper(tool.RequestTemplate.Method)
+		if method == "" {
+			method = "GET"
+		}
+
+		pathItem := doc.Paths[path]
+		if pathItem == nil {
+			pathItem = &openapi3.PathItem{}
+			doc.Paths[path] = pathItem
+		}
+		if pathItem.GetOperation(method) != nil {
+			return nil, fmt.Errorf("tools %q and %q both map to %s %s", pathItem.GetOperation(method).OperationID, tool.Name, method, path)
+		}
+
+		// Without a base URL, the origin of the first absolute tool URL becomes the server
+		operation := exportTool(tool, path, query)
+		if baseURL == "" && server != "" {
+			baseURL = server
+			doc.Servers = openapi3.Servers{{URL: baseURL}}
+		}
+		if server != "" && server != baseURL {
+			operation.Servers = &openapi3.Servers{{URL: server}}
+		}
+		pathItem.SetOperation(method, operation)
+	}
+	return doc, nil
+}
+
+// exportSecurityScheme converts an MCP security scheme, keeping its default credential as an extension
+func exportSecurityScheme(scheme models.SecurityScheme) *openapi3.SecurityScheme {
+	result := &openapi3.SecurityScheme{
+		Type:   scheme.Type,
+		Scheme: scheme.Scheme,
+		In:     scheme.In,
+		Name:   scheme.Name,
+	}
+	if scheme.DefaultCredential != "" {
+		result.Extensions = map[string]any{defaultCredentialExtension: scheme.DefaultCredential}
+	}
+	return result
+}
+
+// exportTool converts a tool to an operation
+func exportTool(tool models.Tool, path string, query map[string]bool) *openapi3.Operation {
+	template := tool.RequestTemplate
+	operation := &openapi3.Operation{
+		OperationID: tool.Name,
+		Description: tool.Description,
+		Responses:   openapi3.Responses{"200": &openapi3.ResponseRef{Value: openapi3.NewResponse().WithDescription("Successful response")}},
+	}
+
+	if len(tool.Annotations) > 0 {
+		operation.Extensions = map[string]any{annotationsExtension: tool.Annotations}
+	}
+	if tool.Cache != nil {
+		if operation.Extensions == nil {
+			operation.Extensions = make(map[string]any)
+		}
+		cache := map[string]any{"ttl": tool.Cache.TTL}
+		if len(tool.Cache.KeyArgs) > 0 {
+			cache["keyArgs"] = tool.Cache.KeyArgs
+		}
+		operation.Extensions[cacheExtension] = cache
+	}
+
+	security := template.Security
+	if security == nil {
+		security = tool.Security
+	}
+	if security != nil {
+		operation.Security = openapi3.NewSecurityRequirements().With(openapi3.NewSecurityRequirement().Authenticate(security.ID, security.Scopes...))
+	}
+
+	contentType, declared := "application/json", false
+	switch {
+	case template.ArgsToFormBody:
+		contentType = "application/x-www-form-urlencoded"
+	case template.Body != "" && strings.HasPrefix(strings.TrimSpace(template.Body), "<"):
+		contentType = "application/xml"
+	}
+	for _, header := range template.Headers {
+		if strings.EqualFold(header.Key, "Content-Type") {
+			contentType, declared = header.Value, true
+		}
+	}
+
+	body := openapi3.NewObjectSchema()
+	for _, arg := range tool.Args {
+		position := argPosition(arg, template, path, query)
+		if bodyPath, ok := strings.CutPrefix(position, "body."); ok {
+			addBodyProperty(body, strings.Split(bodyPath, "."), arg)
+			continue
+		}
+		if position == "body" {
+			body.WithProperty(arg.Name, exportSchema(arg))
+			if arg.Required {
+				body.Required = append(body.Required, arg.Name)
+			}
+			continue
+		}
+
+		param := &openapi3.Parameter{
+			Name:        arg.Name,
+			In:          position,
+			Description: arg.Description,
+			Required:    arg.Required || position == "path",
+			Schema:      openapi3.NewSchemaRef("", exportSchema(arg)),
+		}
+		// The description and examples are kept on the parameter rather than repeated in its schema
+		param.Schema.Value.Description = ""
+		param.Schema.Value.Example = nil
+		if len(arg.Examples) > 1 {
+			param.Examples = make(openapi3.Examples, len(arg.Examples))
+			for i, example := range arg.Examples {
+				param.Examples[fmt.Sprintf("example%d", i+1)] = &openapi3.ExampleRef{Value: openapi3.NewExample(example)}
+			}
+		} else {
+			param.Example = firstExample(arg)
+		}
+		operation.AddParameter(param)
+	}
+	// A declared content type keeps the request body even if no args are sent in it
+	if len(body.Properties) > 0 || declared {
+		operation.RequestBody = &openapi3.RequestBodyRef{Value: openapi3.NewRequestBody().
+			WithRequired(len(body.Required) > 0).
+			WithContent(openapi3.NewContentWithSchema(body, []string{contentType}))}
+	}
+	return operation
+}
+
+// addBodyProperty adds an arg flattened from a nested body property at its path, creating the
+// enclosing objects. An enclosing object is required if one of its properties is.
+func addBodyProperty(body *openapi3.Schema, path []string, arg models.Arg) {
+	object := body
+	for _, name := range path[:len(path)-1] {
+		if arg.Required && !slices.Contains(object.Required, name) {
+			object.Required = append(object.Required, name)
+		}
+		child := object.Properties[name]
+		if child == nil || child.Value == nil {
+			child = openapi3.NewSchemaRef("", openapi3.NewObjectSchema())
+			object.WithPropertyRef(name, child)
+		}
+		object = child.Value
+	}
+	name := path[len(path)-1]
+	object.WithProperty(name, exportSchema(arg))
+	if arg.Required {
+		object.Required = append(object.Required, name)
+	}
+}
+
+// argPosition returns where an arg is sent. Args without a position are placed as the
+// runtime would: in the path if the URL refers to them, otherwise according to the
+// argsTo* flags, and in the query string or the JSON body depending on the method.
+func argPosition(arg models.Arg, template models.RequestTemplate, path string, query map[string]bool) string {
+	if arg.Position != "" {
+		return arg.Position
+	}
+	switch {
+	case strings.Contains(path, "{"+arg.Name+"}"):
+		return "path"
+	case query[arg.Name], template.ArgsToUrlParam:
+		return "query"
+	case template.ArgsToJsonBody, template.ArgsToFormBody, template.Body != "":
+		return "body"
+	}
+	switch strings.ToUpper(template.Method) {
+	case "POST", "PUT", "PATCH":
+		return "body"
+	}
+	return "query"
+}
+
+// exportSchema converts an arg to a schema
+func exportSchema(arg models.Arg) *openapi3.Schema {
+	schema := &openapi3.Schema{
+		Type:        arg.Type,
+		Title:       arg.Title,
+		Description: arg.Description,
+		Format:      arg.Format,
+		Pattern:     arg.Pattern,
+		Default:     arg.Default,
+		Example:     firstExample(arg),
+		Enum:        arg.Enum,
+		Min:         arg.Minimum,
+		Max:         arg.Maximum,
+		MinLength:   arg.MinLength,
+		MaxLength:   arg.MaxLength,
+		MinItems:    arg.MinItems,
+		MaxItems:    arg.MaxItems,
+		UniqueItems: arg.UniqueItems,
+		Nullable:    arg.Nullable,
+	}
+	if arg.Items != nil {
+		schema.Items = openapi3.NewSchemaRef("", exportSchema(*arg.Items))
+	} else if arg.Type == "array" {
+		schema.Items = openapi3.NewSchemaRef("", openapi3.NewStringSchema())
+	}
+
+	if arg.AdditionalProperties != nil {
+		schema.AdditionalProperties.Schema = openapi3.NewSchemaRef("", exportSchema(*arg.AdditionalProperties))
+	}
+
+	names := make([]string, 0, len(arg.Properties))
+	for name := range arg.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		property := arg.Properties[name]
+		schema.WithProperty(name, exportSchema(property))
+		if property.Required {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	return schema
+}
+
+// firstExample returns the first example of an arg, or nil if it has none
+func firstExample(arg models.Arg) any {
+	if len(arg.Examples) > 0 {
+		return arg.Examples[0]
+	}
+	return nil
+}
+
+// splitURL splits a URL template into its server, its OpenAPI path and the args referenced
+// by its query string. {{.args.name}} references become {name} path templates.
+func splitURL(rawURL string) (server, path string, query map[string]bool) {
+	rawURL, rawQuery, _ := strings.Cut(rawURL, "?")
+	query = make(map[string]bool)
+	for _, match := range argReference.FindAllStringSubmatch(rawQuery, -1) {
+		query[match[1]] = true
+	}
+
+	path = argReference.ReplaceAllString(rawURL, "{$1}")
+	if parsed, err := url.Parse(path); err == nil && parsed.Scheme != "" && parsed.Host != "" {
+		server = parsed.Scheme + "://" + parsed.Host
+		path = strings.TrimPrefix(path, server)
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return server, path, query
+}
@@ -0,0 +1,183 @@
+package exporter
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/converter"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+// convert converts a parsed OpenAPI document to an MCP configuration
+func convert(t *testing.T, p *parser.Parser) *models.MCPConfig {
+	config, err := converter.NewConverter(p, models.ConvertOptions{ServerName: "test"}).Convert()
+	assert.NoError(t, err)
+	return config
+}
+
+func TestExportRoundTrip(t *testing.T) {
+	specs := []string{
+		"petstore.json",
+		"path-params.json",
+		"header-params.json",
+		"cookie-params.json",
+		"request-body-types.json",
+		"security-test.json",
+		"param-constraints.json",
+		"annotations.json",
+		"cache.json",
+		"allof-params.json",
+		"tools-args-array-of-object.json",
+		"naming-heuristics.json",
+		"additional-properties.json",
+	}
+
+	for _, spec := range specs {
+		t.Run(spec, func(t *testing.T) {
+			p := parser.NewParser()
+			assert.NoError(t, p.ParseFile("../../test/"+spec))
+			original := convert(t, p)
+
+			doc, err := Export(original)
+			assert.NoError(t, err)
+			assert.NoError(t, doc.Validate(context.Background()))
+
+			// Converting the exported document gives back the same tools, except for response templates
+			data, err := json.Marshal(doc)
+			assert.NoError(t, err)
+			exported := parser.NewParser()
+			assert.NoError(t, exported.Parse(data))
+			roundTrip := convert(t, exported)
+
+			assert.Equal(t, original.Server.BaseURL, roundTrip.Server.BaseURL)
+			assert.Equal(t, original.Server.SecuritySchemes, roundTrip.Server.SecuritySchemes)
+			assert.Equal(t, len(original.Tools), len(roundTrip.Tools))
+			for i := range original.Tools {
+				original.Tools[i].ResponseTemplate = models.ResponseTemplate{}
+				roundTrip.Tools[i].ResponseTemplate = models.ResponseTemplate{}
+			}
+			assert.Equal(t, original.Tools, roundTrip.Tools)
+		})
+	}
+}
+
+func TestExportHandWrittenConfig(t *testing.T) {
+	config := &models.MCPConfig{
+		Server: models.ServerConfig{
+			Name: "weather",
+			SecuritySchemes: []models.SecurityScheme{
+				{ID: "key", Type: "apiKey", In: "query", Name: "appid", DefaultCredential: "{{.config.appid}}"},
+			},
+		},
+		Tools: []models.Tool{
+			{
+				Name:        "getForecast",
+				Description: "Get the forecast of a city",
+				Args: []models.Arg{
+					{Name: "city", Description: "City name", Type: "string", Required: true},
+					{Name: "days", Description: "Number of days", Type: "integer"},
+				},
+				RequestTemplate: models.RequestTemplate{
+					URL:      "https://api.weather.example.com/cities/{{.args.city}}/forecast?days={{.args.days}}",
+					Method:   "GET",
+					Security: &models.ToolSecurityRequirement{ID: "key"},
+				},
+			},
+			{
+				Name: "createAlert",
+				Args: []models.Arg{
+					{Name: "city", Type: "string", Required: true},
+					{Name: "threshold", Type: "number"},
+				},
+				RequestTemplate: models.RequestTemplate{
+					URL:    "https://alerts.example.com/alerts",
+					Method: "POST",
+				},
+			},
+		},
+	}
+
+	doc, err := Export(config)
+	assert.NoError(t, err)
+	assert.NoError(t, doc.Validate(context.Background()))
+
+	assert.Equal(t, "https://api.weather.example.com", doc.Servers[0].URL)
+	assert.Equal(t, "{{.config.appid}}", doc.Components.SecuritySchemes["key"].Value.Extensions[defaultCredentialExtension])
+
+	forecast := doc.Paths["/cities/{city}/forecast"].Get
+	assert.Equal(t, "path", forecast.Parameters.GetByInAndName("path", "city").In)
+	assert.NotNil(t, forecast.Parameters.GetByInAndName("query", "days"))
+	assert.Equal(t, openapi3.SecurityRequirements{{"key": []string{}}}, *forecast.Security)
+
+	alert := doc.Paths["/alerts"].Post
+	assert.Equal(t, "https://alerts.example.com", (*alert.Servers)[0].URL)
+	body := alert.RequestBody.Value.Content.Get("application/json").Schema.Value
+	assert.Equal(t, []string{"city"}, body.Required)
+	assert.Contains(t, body.Properties, "threshold")
+}
+
+func TestExportBodyPaths(t *testing.T) {
+	options := models.ConvertOptions{ServerName: "test", FlattenBodyDepth: 2}
+	p := parser.NewParser()
+	assert.NoError(t, p.ParseFile("../../test/nested-body.json"))
+	original, err := converter.NewConverter(p, options).Convert()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	doc, err := Export(original)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NoError(t, doc.Validate(context.Background()))
+	body := doc.Paths["/users"].Post.RequestBody.Value.Content.Get("application/json").Schema.Value
+	assert.Equal(t, []string{"user"}, body.Required)
+	assert.Equal(t, []string{"address", "name"}, body.Properties["user"].Value.Required)
+	assert.Contains(t, body.Properties["user"].Value.Properties["address"].Value.Properties, "geo")
+
+	// Flattening the exported body gives back the same args
+	data, err := json.Marshal(doc)
+	assert.NoError(t, err)
+	exported := parser.NewParser()
+	assert.NoError(t, exported.Parse(data))
+	roundTrip, err := converter.NewConverter(exported, options).Convert()
+	if assert.NoError(t, err) {
+		assert.Equal(t, original.Tools[0].Args, roundTrip.Tools[0].Args)
+	}
+}
+
+func TestExportDuplicateOperations(t *testing.T) {
+	config := &models.MCPConfig{
+		Tools: []models.Tool{
+			{Name: "listUsers", RequestTemplate: models.RequestTemplate{URL: "/users", Method: "GET"}},
+			{Name: "getUsers", RequestTemplate: models.RequestTemplate{URL: "/users", Method: "get"}},
+		},
+	}
+	_, err := Export(config)
+	assert.Error(t, err)
+}
+
+func TestSplitURL(t *testing.T) {
+	tests := []struct {
+		url    string
+		server string
+		path   string
+		query  map[string]bool
+	}{
+		{url: "/pets/{petId}", path: "/pets/{petId}", query: map[string]bool{}},
+		{url: "https://api.example.com/v1/pets/{{.args.id}}?q={{ .args.q }}", server: "https://api.example.com", path: "/v1/pets/{id}", query: map[string]bool{"q": true}},
+		{url: "pets", path: "/pets", query: map[string]bool{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			server, path, query := splitURL(tt.url)
+			assert.Equal(t, tt.server, server)
+			assert.Equal(t, tt.path, path)
+			assert.Equal(t, tt.query, query)
+		})
+	}
+}
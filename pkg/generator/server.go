@@ -0,0 +1,97 @@
+// Package generator generates standalone programs from MCP configurations.
+package generator
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/version"
+)
+
+//go:embed templates
+var templates embed.FS
+
+// Source files of a generated server
+const (
+	ServerMainFile   = "main.go"
+	ServerModuleFile = "go.mod"
+	ServerConfigFile = "mcp-config.json"
+)
+
+// serverData is the data passed to the server templates
+type serverData struct {
+	Generator string
+	Module    string
+	Name      string
+}
+
+// Server generates a self-contained Go MCP server implementing the tools of a configuration
+// by calling the HTTP API. It returns the content of the generated files keyed by file name.
+func Server(config *models.MCPConfig, module string) (map[string][]byte, error) {
+	if module == "" {
+		module = ModuleName(config.Server.Name)
+	}
+	data := serverData{
+		Generator: version.Name,
+		Module:    module,
+		Name:      strings.Join(strings.Fields(config.Server.Name), " "),
+	}
+
+	mainSource, err := execute("templates/main.go.tmpl", data)
+	if err != nil {
+		return nil, err
+	}
+	mainSource, err = format.Source(mainSource)
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated server: %w", err)
+	}
+
+	goMod, err := execute("templates/go.mod.tmpl", data)
+	if err != nil {
+		return nil, err
+	}
+
+	// The generated server builds input schemas from args, so shared schemas are inlined
+	configJSON, err := json.MarshalIndent(config.InlineSchemas(), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode MCP configuration: %w", err)
+	}
+
+	return map[string][]byte{
+		ServerMainFile:   mainSource,
+		ServerModuleFile: goMod,
+		ServerConfigFile: append(configJSON, '\n'),
+	}, nil
+}
+
+// execute renders an embedded template.
+// Templates use [[ ]] delimiters since the generated code contains Go template actions itself.
+func execute(name string, data any) ([]byte, error) {
+	tmpl, err := template.New(name[strings.LastIndex(name, "/")+1:]).Delims("[[", "]]").ParseFS(templates, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", name, err)
+	}
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, data); err != nil {
+		return nil, fmt.Errorf("failed to execute template %s: %w", name, err)
+	}
+	return buffer.Bytes(), nil
+}
+
+var nonModuleChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// ModuleName derives a Go module name from a server name
+func ModuleName(name string) string {
+	module := strings.Trim(nonModuleChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if module == "" {
+		return "mcp-server"
+	}
+	return module
+}
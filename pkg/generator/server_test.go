@@ -0,0 +1,64 @@
+package generator
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/converter"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func petstoreConfig(t *testing.T) *models.MCPConfig {
+	p := parser.NewParser()
+	assert.NoError(t, p.ParseFile("../../test/petstore.json"))
+	config, err := converter.NewConverter(p, models.ConvertOptions{}).Convert()
+	assert.NoError(t, err)
+	return config
+}
+
+func TestServer(t *testing.T) {
+	config := petstoreConfig(t)
+
+	files, err := Server(config, "example.com/petstore-mcp")
+	assert.NoError(t, err)
+	assert.Len(t, files, 3)
+	assert.Equal(t, "module example.com/petstore-mcp\n\ngo 1.21\n", string(files[ServerModuleFile]))
+	assert.Contains(t, string(files[ServerMainFile]), "// Command example.com/petstore-mcp is a standalone MCP server for petstore-api")
+
+	var embedded models.MCPConfig
+	assert.NoError(t, json.Unmarshal(files[ServerConfigFile], &embedded))
+	assert.Len(t, embedded.Tools, len(config.Tools))
+	assert.Equal(t, config.Tools[0].Args, embedded.Tools[0].Args)
+}
+
+func TestServerBuilds(t *testing.T) {
+	if testing.Short() {
+		t.Skip("building the generated server is slow")
+	}
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go toolchain not available")
+	}
+
+	files, err := Server(petstoreConfig(t), "")
+	assert.NoError(t, err)
+
+	dir := t.TempDir()
+	for name, data := range files {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), data, 0644))
+	}
+	cmd := exec.Command(goTool, "vet", ".")
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	assert.NoError(t, err, string(output))
+}
+
+func TestModuleName(t *testing.T) {
+	assert.Equal(t, "petstore-api-a-sample-api", ModuleName("Petstore API - A sample API"))
+	assert.Equal(t, "mcp-server", ModuleName("---"))
+}
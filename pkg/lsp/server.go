@@ -0,0 +1,258 @@
+// Package lsp implements a minimal Language Server Protocol server that validates
+// MCP configuration files as they are edited.
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf16"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/validator"
+)
+
+const (
+	// codeParseError is the JSON-RPC error code for messages that are not valid JSON
+	codeParseError = -32700
+	// codeMethodNotFound is the JSON-RPC error code for unsupported methods
+	codeMethodNotFound = -32601
+)
+
+// Server is a validation-only language server speaking JSON-RPC over a byte stream
+type Server struct {
+	validator *validator.Validator
+
+	reader *bufio.Reader
+	writer io.Writer
+	mu     sync.Mutex
+
+	documents map[string]string
+}
+
+// NewServer creates a language server reading requests from r and writing responses to w
+func NewServer(r io.Reader, w io.Writer) (*Server, error) {
+	v, err := validator.New()
+	if err != nil {
+		return nil, err
+	}
+	return &Server{
+		validator: v,
+		reader:    bufio.NewReader(r),
+		writer:    w,
+		documents: make(map[string]string),
+	}, nil
+}
+
+// message is a JSON-RPC request, notification or response
+type message struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id,omitempty"`
+	Method  string           `json:"method,omitempty"`
+	Params  json.RawMessage  `json:"params,omitempty"`
+	Result  any              `json:"result,omitempty"`
+	Error   *responseError   `json:"error,omitempty"`
+}
+
+type responseError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type textDocumentItem struct {
+	URI  string `json:"uri"`
+	Text string `json:"text"`
+}
+
+type didOpenParams struct {
+	TextDocument textDocumentItem `json:"textDocument"`
+}
+
+type didChangeParams struct {
+	TextDocument   textDocumentItem `json:"textDocument"`
+	ContentChanges []struct {
+		Text string `json:"text"`
+	} `json:"contentChanges"`
+}
+
+type position struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+type lspRange struct {
+	Start position `json:"start"`
+	End   position `json:"end"`
+}
+
+type lspDiagnostic struct {
+	Range    lspRange `json:"range"`
+	Severity int      `json:"severity"`
+	Source   string   `json:"source"`
+	Message  string   `json:"message"`
+}
+
+type publishDiagnosticsParams struct {
+	URI         string          `json:"uri"`
+	Diagnostics []lspDiagnostic `json:"diagnostics"`
+}
+
+// Run serves requests until the client sends exit or the input stream ends
+func (s *Server) Run() error {
+	for {
+		body, err := s.read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		var msg *message
+		if err := json.Unmarshal(body, &msg); err != nil || msg == nil {
+			// The id of a malformed message is unknown, so the error is sent with a null id
+			null := json.RawMessage("null")
+			if err := s.replyError(&null, codeParseError, "invalid message"); err != nil {
+				return err
+			}
+			continue
+		}
+		if msg.Method == "exit" {
+			return nil
+		}
+		if err := s.handle(msg); err != nil {
+			return err
+		}
+	}
+}
+
+// handle dispatches a single request or notification
+func (s *Server) handle(msg *message) error {
+	switch msg.Method {
+	case "initialize":
+		return s.reply(msg.ID, map[string]any{
+			"capabilities": map[string]any{
+				// Full document sync
+				"textDocumentSync": map[string]any{"openClose": true, "change": 1},
+			},
+			"serverInfo": map[string]any{"name": "openapi-to-mcp"},
+		})
+	case "shutdown":
+		return s.reply(msg.ID, nil)
+	case "textDocument/didOpen":
+		var params didOpenParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil
+		}
+		s.documents[params.TextDocument.URI] = params.TextDocument.Text
+		return s.publish(params.TextDocument.URI)
+	case "textDocument/didChange":
+		var params didChangeParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil || len(params.ContentChanges) == 0 {
+			return nil
+		}
+		// With full sync the last change holds the whole document
+		s.documents[params.TextDocument.URI] = params.ContentChanges[len(params.ContentChanges)-1].Text
+		return s.publish(params.TextDocument.URI)
+	case "textDocument/didClose":
+		var params didOpenParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil
+		}
+		delete(s.documents, params.TextDocument.URI)
+		return s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{
+			URI:         params.TextDocument.URI,
+			Diagnostics: []lspDiagnostic{},
+		})
+	}
+
+	// Requests must be answered even when unsupported; notifications are ignored
+	if msg.ID != nil {
+		return s.replyError(msg.ID, codeMethodNotFound, fmt.Sprintf("method %q not supported", msg.Method))
+	}
+	return nil
+}
+
+// publish validates a document and sends its diagnostics to the client
+func (s *Server) publish(uri string) error {
+	diagnostics := []lspDiagnostic{}
+	for _, d := range s.validator.Validate([]byte(s.documents[uri])) {
+		diagnostics = append(diagnostics, lspDiagnostic{
+			Range:    lineRange(s.documents[uri], d.Line, d.Column),
+			Severity: d.Severity,
+			Source:   "openapi-to-mcp",
+			Message:  d.Message,
+		})
+	}
+	return s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: uri, Diagnostics: diagnostics})
+}
+
+// lineRange returns the range from a diagnostic to the end of its line so the rest of the line is
+// highlighted. The column of a diagnostic counts runes while LSP positions count UTF-16 code units.
+func lineRange(text string, line, column int) lspRange {
+	start := position{Line: line, Character: column}
+	lines := strings.Split(text, "\n")
+	if line >= len(lines) {
+		return lspRange{Start: start, End: start}
+	}
+	runes := []rune(strings.TrimRight(lines[line], "\r"))
+	if column <= len(runes) {
+		start.Character = len(utf16.Encode(runes[:column]))
+	}
+	end := position{Line: line, Character: max(len(utf16.Encode(runes)), start.Character)}
+	return lspRange{Start: start, End: end}
+}
+
+// read reads the body of one Content-Length framed message
+func (s *Server) read() ([]byte, error) {
+	header, err := textproto.NewReader(s.reader).ReadMIMEHeader()
+	if err != nil {
+		return nil, err
+	}
+	length, err := strconv.Atoi(header.Get("Content-Length"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid Content-Length header: %w", err)
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(s.reader, body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+func (s *Server) reply(id *json.RawMessage, result any) error {
+	if result == nil {
+		result = json.RawMessage("null")
+	}
+	return s.write(message{JSONRPC: "2.0", ID: id, Result: result})
+}
+
+func (s *Server) replyError(id *json.RawMessage, code int, text string) error {
+	return s.write(message{JSONRPC: "2.0", ID: id, Error: &responseError{Code: code, Message: text}})
+}
+
+func (s *Server) notify(method string, params any) error {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	return s.write(message{JSONRPC: "2.0", Method: method, Params: data})
+}
+
+// write sends one Content-Length framed message
+func (s *Server) write(msg message) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := fmt.Fprintf(s.writer, "Content-Length: %d\r\n\r\n", len(body)); err != nil {
+		return err
+	}
+	_, err = s.writer.Write(body)
+	return err
+}
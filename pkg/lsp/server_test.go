@@ -0,0 +1,128 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func frame(t *testing.T, msg map[string]any) string {
+	body, err := json.Marshal(msg)
+	assert.NoError(t, err)
+	return fmt.Sprintf("Content-Length: %d\r\n\r\n%s", len(body), body)
+}
+
+func readMessages(t *testing.T, data []byte) []map[string]any {
+	var messages []map[string]any
+	reader := bufio.NewReader(bytes.NewReader(data))
+	for {
+		header, err := textproto.NewReader(reader).ReadMIMEHeader()
+		if err == io.EOF {
+			return messages
+		}
+		assert.NoError(t, err)
+		length, _ := strconv.Atoi(header.Get("Content-Length"))
+		body := make([]byte, length)
+		_, err = io.ReadFull(reader, body)
+		assert.NoError(t, err)
+		var msg map[string]any
+		assert.NoError(t, json.Unmarshal(body, &msg))
+		messages = append(messages, msg)
+	}
+}
+
+func TestServerPublishesDiagnostics(t *testing.T) {
+	input := frame(t, map[string]any{"jsonrpc": "2.0", "id": 1, "method": "initialize", "params": map[string]any{}}) +
+		frame(t, map[string]any{"jsonrpc": "2.0", "method": "textDocument/didOpen", "params": map[string]any{
+			"textDocument": map[string]any{"uri": "file:///mcp.yaml", "text": "server:\n  name: demo\ntools:\n  - description: no name\n"},
+		}}) +
+		frame(t, map[string]any{"jsonrpc": "2.0", "id": 2, "method": "shutdown"}) +
+		frame(t, map[string]any{"jsonrpc": "2.0", "method": "exit"})
+
+	var output bytes.Buffer
+	server, err := NewServer(bytes.NewBufferString(input), &output)
+	assert.NoError(t, err)
+	assert.NoError(t, server.Run())
+
+	messages := readMessages(t, output.Bytes())
+	assert.Len(t, messages, 3)
+	assert.Contains(t, messages[0]["result"], "capabilities")
+
+	assert.Equal(t, "textDocument/publishDiagnostics", messages[1]["method"])
+	params := messages[1]["params"].(map[string]any)
+	diagnostics := params["diagnostics"].([]any)
+	assert.Len(t, diagnostics, 1)
+	assert.Equal(t, `missing required property "name"`, diagnostics[0].(map[string]any)["message"])
+
+	assert.Equal(t, float64(2), messages[2]["id"])
+}
+
+func TestServerRepliesToMalformedMessages(t *testing.T) {
+	input := "Content-Length: 9\r\n\r\n{\"id\": 1," +
+		frame(t, map[string]any{"jsonrpc": "2.0", "id": 2, "method": "shutdown"}) +
+		frame(t, map[string]any{"jsonrpc": "2.0", "method": "exit"})
+
+	var output bytes.Buffer
+	server, err := NewServer(bytes.NewBufferString(input), &output)
+	assert.NoError(t, err)
+	assert.NoError(t, server.Run())
+
+	messages := readMessages(t, output.Bytes())
+	if assert.Len(t, messages, 2) {
+		assert.Contains(t, messages[0], "id")
+		assert.Nil(t, messages[0]["id"])
+		assert.Equal(t, float64(-32700), messages[0]["error"].(map[string]any)["code"])
+		assert.Equal(t, float64(2), messages[1]["id"])
+	}
+}
+
+func TestLineRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		line     int
+		column   int
+		expected lspRange
+	}{
+		{
+			name:     "ascii",
+			text:     "name: demo\r\n",
+			line:     0,
+			column:   6,
+			expected: lspRange{Start: position{0, 6}, End: position{0, 10}},
+		},
+		{
+			name:     "characters outside the basic plane count twice",
+			text:     "a: 😀\nname: 😀😀",
+			line:     1,
+			column:   6,
+			expected: lspRange{Start: position{1, 6}, End: position{1, 10}},
+		},
+		{
+			name:     "column after characters outside the basic plane",
+			text:     "😀: x",
+			line:     0,
+			column:   3,
+			expected: lspRange{Start: position{0, 4}, End: position{0, 5}},
+		},
+		{
+			name:     "line out of range",
+			text:     "name: demo",
+			line:     3,
+			column:   2,
+			expected: lspRange{Start: position{3, 2}, End: position{3, 2}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, lineRange(tt.text, tt.line, tt.column))
+		})
+	}
+}
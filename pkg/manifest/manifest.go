@@ -0,0 +1,178 @@
+// Package manifest wraps MCP configurations in Kubernetes manifests that can be applied to Higress.
+package manifest
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+)
+
+// Manifest kinds
+const (
+	KindWasmPlugin = "wasmplugin"
+	KindConfigMap  = "configmap"
+)
+
+// Defaults of the manifest options
+const (
+	DefaultNamespace = "higress-system"
+	DefaultPluginURL = "oci://higress-registry.cn-hangzhou.cr.aliyuncs.com/plugins/mcp-server:1.0.0"
+	DefaultPhase     = "UNSPECIFIED_PHASE"
+	DefaultPriority  = 30
+)
+
+// Options configures the generated manifest
+type Options struct {
+	Kind      string // KindWasmPlugin or KindConfigMap
+	Name      string // Resource name (default: derived from the server name)
+	Namespace string // Resource namespace (default: DefaultNamespace)
+
+	// WasmPlugin specific
+	PluginURL string   // Image of the MCP server plugin (default: DefaultPluginURL)
+	Phase     string   // Plugin phase, e.g. AUTHN, AUTHZ, STATS (default: DefaultPhase)
+	Priority  int      // Plugin priority within its phase (default: DefaultPriority)
+	Domains   []string // Domains the configuration applies to
+	Services  []string // Services the configuration applies to
+	Ingresses []string // Ingresses the configuration applies to
+}
+
+// ObjectMeta holds the metadata of a Kubernetes object
+type ObjectMeta struct {
+	Name      string `yaml:"name" json:"name"`
+	Namespace string `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+}
+
+// WasmPlugin is a Higress WasmPlugin resource
+type WasmPlugin struct {
+	APIVersion string         `yaml:"apiVersion" json:"apiVersion"`
+	Kind       string         `yaml:"kind" json:"kind"`
+	Metadata   ObjectMeta     `yaml:"metadata" json:"metadata"`
+	Spec       WasmPluginSpec `yaml:"spec" json:"spec"`
+}
+
+// WasmPluginSpec is the spec of a WasmPlugin
+type WasmPluginSpec struct {
+	DefaultConfig        *models.MCPConfig `yaml:"defaultConfig,omitempty" json:"defaultConfig,omitempty"`
+	DefaultConfigDisable bool              `yaml:"defaultConfigDisable" json:"defaultConfigDisable"`
+	MatchRules           []MatchRule       `yaml:"matchRules,omitempty" json:"matchRules,omitempty"`
+	Phase                string            `yaml:"phase" json:"phase"`
+	Priority             int               `yaml:"priority" json:"priority"`
+	URL                  string            `yaml:"url" json:"url"`
+}
+
+// MatchRule applies a plugin configuration to domains, services or ingresses
+type MatchRule struct {
+	Config        *models.MCPConfig `yaml:"config" json:"config"`
+	ConfigDisable bool              `yaml:"configDisable" json:"configDisable"`
+	Domain        []string          `yaml:"domain,omitempty" json:"domain,omitempty"`
+	Service       []string          `yaml:"service,omitempty" json:"service,omitempty"`
+	Ingress       []string          `yaml:"ingress,omitempty" json:"ingress,omitempty"`
+}
+
+// ConfigMap is a Kubernetes ConfigMap
+type ConfigMap struct {
+	APIVersion string            `yaml:"apiVersion" json:"apiVersion"`
+	Kind       string            `yaml:"kind" json:"kind"`
+	Metadata   ObjectMeta        `yaml:"metadata" json:"metadata"`
+	Data       map[string]string `yaml:"data" json:"data"`
+}
+
+// Wrap wraps an MCP configuration in a manifest of the kind selected by the options
+func Wrap(config *models.MCPConfig, options Options) (any, error) {
+	name := options.Name
+	if name == "" {
+		name = ResourceName(config.Server.Name)
+	}
+	namespace := options.Namespace
+	if namespace == "" {
+		namespace = DefaultNamespace
+	}
+	metadata := ObjectMeta{Name: name, Namespace: namespace}
+
+	switch options.Kind {
+	case KindWasmPlugin:
+		return wasmPlugin(config, metadata, options), nil
+	case KindConfigMap:
+		var buffer bytes.Buffer
+		encoder := yaml.NewEncoder(&buffer)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(config); err != nil {
+			return nil, fmt.Errorf("failed to encode MCP configuration: %w", err)
+		}
+		return &ConfigMap{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+			Metadata:   metadata,
+			Data:       map[string]string{name + ".yaml": buffer.String()},
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown manifest kind %q, expected %s or %s", options.Kind, KindWasmPlugin, KindConfigMap)
+}
+
+// wasmPlugin builds a WasmPlugin applying the configuration to the matched routes,
+// or to all routes when no match is given
+func wasmPlugin(config *models.MCPConfig, metadata ObjectMeta, options Options) *WasmPlugin {
+	plugin := &WasmPlugin{
+		APIVersion: "extensions.higress.io/v1alpha1",
+		Kind:       "WasmPlugin",
+		Metadata:   metadata,
+		Spec: WasmPluginSpec{
+			Phase:    options.Phase,
+			Priority: options.Priority,
+			URL:      options.PluginURL,
+		},
+	}
+	if plugin.Spec.Phase == "" {
+		plugin.Spec.Phase = DefaultPhase
+	}
+	if plugin.Spec.Priority == 0 {
+		plugin.Spec.Priority = DefaultPriority
+	}
+	if plugin.Spec.URL == "" {
+		plugin.Spec.URL = DefaultPluginURL
+	}
+
+	if len(options.Domains) == 0 && len(options.Services) == 0 && len(options.Ingresses) == 0 {
+		plugin.Spec.DefaultConfig = config
+		return plugin
+	}
+
+	// Only the matched routes are served, so the default configuration is disabled.
+	// Each match rule selects a single kind of route.
+	plugin.Spec.DefaultConfigDisable = true
+	if len(options.Domains) > 0 {
+		plugin.Spec.MatchRules = append(plugin.Spec.MatchRules, MatchRule{Config: config, Domain: options.Domains})
+	}
+	if len(options.Services) > 0 {
+		plugin.Spec.MatchRules = append(plugin.Spec.MatchRules, MatchRule{Config: config, Service: options.Services})
+	}
+	if len(options.Ingresses) > 0 {
+		plugin.Spec.MatchRules = append(plugin.Spec.MatchRules, MatchRule{Config: config, Ingress: options.Ingresses})
+	}
+	return plugin
+}
+
+// ResourceName derives a Kubernetes resource name from a server name
+func ResourceName(serverName string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(serverName) {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		} else if b.Len() > 0 && !strings.HasSuffix(b.String(), "-") {
+			b.WriteByte('-')
+		}
+	}
+	name := strings.Trim(b.String(), "-")
+	// Resource names are limited to 63 characters
+	if len(name) > 63 {
+		name = strings.TrimRight(name[:63], "-")
+	}
+	if name == "" {
+		return "mcp-server"
+	}
+	return name
+}
@@ -0,0 +1,93 @@
+package manifest
+
+import (
+	"testing"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func testConfig() *models.MCPConfig {
+	return &models.MCPConfig{
+		Server: models.ServerConfig{Name: "Petstore API"},
+		Tools: []models.Tool{
+			{Name: "listPets", RequestTemplate: models.RequestTemplate{URL: "/pets", Method: "GET"}},
+		},
+	}
+}
+
+func TestWasmPlugin(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  Options
+		expected WasmPluginSpec
+	}{
+		{
+			name:    "Default config for all routes",
+			options: Options{Kind: KindWasmPlugin},
+			expected: WasmPluginSpec{
+				DefaultConfig: testConfig(),
+				Phase:         DefaultPhase,
+				Priority:      DefaultPriority,
+				URL:           DefaultPluginURL,
+			},
+		},
+		{
+			name: "Match rules per route kind",
+			options: Options{
+				Kind:      KindWasmPlugin,
+				Phase:     "AUTHN",
+				Priority:  100,
+				PluginURL: "oci://registry.example.com/mcp-server:2.0.0",
+				Domains:   []string{"api.example.com"},
+				Ingresses: []string{"default/petstore"},
+			},
+			expected: WasmPluginSpec{
+				DefaultConfigDisable: true,
+				MatchRules: []MatchRule{
+					{Config: testConfig(), Domain: []string{"api.example.com"}},
+					{Config: testConfig(), Ingress: []string{"default/petstore"}},
+				},
+				Phase:    "AUTHN",
+				Priority: 100,
+				URL:      "oci://registry.example.com/mcp-server:2.0.0",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := Wrap(testConfig(), tc.options)
+			assert.NoError(t, err)
+			plugin, ok := result.(*WasmPlugin)
+			assert.True(t, ok)
+			assert.Equal(t, "extensions.higress.io/v1alpha1", plugin.APIVersion)
+			assert.Equal(t, ObjectMeta{Name: "petstore-api", Namespace: DefaultNamespace}, plugin.Metadata)
+			assert.Equal(t, tc.expected, plugin.Spec)
+		})
+	}
+}
+
+func TestConfigMap(t *testing.T) {
+	result, err := Wrap(testConfig(), Options{Kind: KindConfigMap, Name: "petstore", Namespace: "apis"})
+	assert.NoError(t, err)
+	configMap, ok := result.(*ConfigMap)
+	assert.True(t, ok)
+	assert.Equal(t, ObjectMeta{Name: "petstore", Namespace: "apis"}, configMap.Metadata)
+
+	var config models.MCPConfig
+	assert.NoError(t, yaml.Unmarshal([]byte(configMap.Data["petstore.yaml"]), &config))
+	assert.Equal(t, "listPets", config.Tools[0].Name)
+}
+
+func TestUnknownKind(t *testing.T) {
+	_, err := Wrap(testConfig(), Options{Kind: "deployment"})
+	assert.EqualError(t, err, `unknown manifest kind "deployment", expected wasmplugin or configmap`)
+}
+
+func TestResourceName(t *testing.T) {
+	assert.Equal(t, "petstore-api-a-sample-api", ResourceName("Petstore API - A sample API"))
+	assert.Equal(t, "mcp-server", ResourceName("***"))
+	assert.Len(t, ResourceName("a very long server name that certainly exceeds the kubernetes limit"), 63)
+}
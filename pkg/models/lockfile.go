@@ -0,0 +1,10 @@
+package models
+
+// LockFile records the hash of the source operation of each generated tool, so that incremental
+// conversions only regenerate the tools whose operations changed
+type LockFile struct {
+	// Settings is the hash of the conversion options, the template, the transformers and the
+	// document-level security and servers; every tool is regenerated when it changes
+	Settings string            `yaml:"settings,omitempty" json:"settings,omitempty"`
+	Tools    map[string]string `yaml:"tools" json:"tools"` // Hash of the operation of each tool, by tool name
+}
@@ -2,21 +2,87 @@ package models
 
 // MCPConfig represents the top-level MCP server configuration
 type MCPConfig struct {
-	ToolSet *ToolSetConfig `yaml:"toolSet,omitempty" json:"toolSet,omitempty"`
-	Server  ServerConfig   `yaml:"server,omitempty" json:"server,omitempty"`
-	Tools   []Tool         `yaml:"tools,omitempty" json:"tools,omitempty"`
+	ToolSet   *ToolSetConfig `yaml:"toolSet,omitempty" json:"toolSet,omitempty"`
+	Server    ServerConfig   `yaml:"server,omitempty" json:"server,omitempty"`
+	Tools     []Tool         `yaml:"tools,omitempty" json:"tools,omitempty"`
+	Resources []Resource     `yaml:"resources,omitempty" json:"resources,omitempty"`
+	Prompts   []Prompt       `yaml:"prompts,omitempty" json:"prompts,omitempty"`
+	Events    []Event        `yaml:"events,omitempty" json:"events,omitempty"`
+	// Schemas holds object definitions shared by the args referencing them with ref
+	Schemas  map[string]Schema `yaml:"schemas,omitempty" json:"schemas,omitempty"`
+	Metadata *Metadata         `yaml:"metadata,omitempty" json:"metadata,omitempty"`
+}
+
+// Schema is an object definition shared by several args
+type Schema struct {
+	Properties map[string]Arg `yaml:"properties" json:"properties"`
+}
+
+// Metadata records which generator and options produced a configuration
+type Metadata struct {
+	Generator GeneratorInfo  `yaml:"generator" json:"generator"`
+	Sources   []string       `yaml:"sources,omitempty" json:"sources,omitempty"` // Input specifications
+	Options   map[string]any `yaml:"options,omitempty" json:"options,omitempty"` // Conversion options that were set
+}
+
+// GeneratorInfo identifies the binary that generated a configuration
+type GeneratorInfo struct {
+	Name      string `yaml:"name" json:"name"`
+	Version   string `yaml:"version" json:"version"`
+	Commit    string `yaml:"commit,omitempty" json:"commit,omitempty"`
+	BuildDate string `yaml:"buildDate,omitempty" json:"buildDate,omitempty"`
+}
+
+// Resource represents an MCP resource backed by a parameterless GET endpoint
+type Resource struct {
+	URI         string `yaml:"uri" json:"uri"`
+	Name        string `yaml:"name" json:"name"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	MimeType    string `yaml:"mimeType,omitempty" json:"mimeType,omitempty"`
+}
+
+// Prompt represents an MCP prompt giving ready-made guidance for invoking a tool
+type Prompt struct {
+	Name        string           `yaml:"name" json:"name"`
+	Description string           `yaml:"description,omitempty" json:"description,omitempty"`
+	Arguments   []PromptArgument `yaml:"arguments,omitempty" json:"arguments,omitempty"`
+	Messages    []PromptMessage  `yaml:"messages" json:"messages"`
+}
+
+// PromptArgument represents an argument accepted by a prompt
+type PromptArgument struct {
+	Name        string `yaml:"name" json:"name"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	Required    bool   `yaml:"required,omitempty" json:"required,omitempty"`
+}
+
+// PromptMessage represents a message returned when a prompt is used
+type PromptMessage struct {
+	Role    string `yaml:"role" json:"role"` // "user" or "assistant"
+	Content string `yaml:"content" json:"content"`
+}
+
+// Event describes a request the API sends to the client, registered by a tool (callback) or out of band (webhook)
+type Event struct {
+	Name        string `yaml:"name" json:"name"`
+	Kind        string `yaml:"kind" json:"kind"` // "callback" or "webhook"
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	Tool        string `yaml:"tool,omitempty" json:"tool,omitempty"` // Tool registering the callback
+	URL         string `yaml:"url,omitempty" json:"url,omitempty"`   // Runtime expression of the callback URL, e.g. "{$request.body#/callbackUrl}"
+	Method      string `yaml:"method" json:"method"`
+	Payload     []Arg  `yaml:"payload,omitempty" json:"payload,omitempty"` // Parameters and body of the request
 }
 
 // ToolSetConfig defines the configuration for a toolset.
 type ToolSetConfig struct {
-	Name        string             `json:"name,omitempty"`
-	ServerTools []ServerToolConfig `json:"serverTools,omitempty"`
+	Name        string             `yaml:"name,omitempty" json:"name,omitempty"`
+	ServerTools []ServerToolConfig `yaml:"serverTools,omitempty" json:"serverTools,omitempty"`
 }
 
 // ServerToolConfig specifies which tools from a server to include in a toolset.
 type ServerToolConfig struct {
-	ServerName string   `json:"serverName,omitempty"`
-	Tools      []string `json:"tools,omitempty"`
+	ServerName string   `yaml:"serverName,omitempty" json:"serverName,omitempty"`
+	Tools      []string `yaml:"tools,omitempty" json:"tools,omitempty"`
 }
 
 // ServerConfig represents the MCP server configuration
@@ -36,6 +102,15 @@ type SecurityScheme struct {
 	In                string `yaml:"in,omitempty" json:"in,omitempty"`         // e.g., "header", "query", "cookie" for "apiKey" type
 	Name              string `yaml:"name,omitempty" json:"name,omitempty"`     // Name of the header, query parameter or cookie for "apiKey" type
 	DefaultCredential string `yaml:"defaultCredential,omitempty" json:"defaultCredential,omitempty"`
+	// CredentialFrom references a credential managed by the deployment platform; it takes precedence over DefaultCredential
+	CredentialFrom *CredentialSource `yaml:"credentialFrom,omitempty" json:"credentialFrom,omitempty"`
+}
+
+// CredentialSource references a credential instead of embedding it. Exactly one field is set.
+type CredentialSource struct {
+	Env    string `yaml:"env,omitempty" json:"env,omitempty"`       // Name of the environment variable holding the credential
+	File   string `yaml:"file,omitempty" json:"file,omitempty"`     // Path of the file holding the credential
+	Secret string `yaml:"secret,omitempty" json:"secret,omitempty"` // Secret of the deployment platform, e.g. "higress-system/petstore/api-key"
 }
 
 // Tool represents an MCP tool configuration
@@ -48,6 +123,14 @@ type Tool struct {
 	ResponseTemplate      ResponseTemplate         `yaml:"responseTemplate" json:"responseTemplate,omitempty"`
 	ErrorResponseTemplate *string                  `yaml:"errorResponseTemplate,omitempty" json:"errorResponseTemplate,omitempty"`
 	Security              *ToolSecurityRequirement `yaml:"security,omitempty" json:"security,omitempty"`
+	Cache                 *CachePolicy             `yaml:"cache,omitempty" json:"cache,omitempty"`
+	InputSchema           map[string]any           `yaml:"inputSchema,omitempty" json:"inputSchema,omitempty"` // JSON Schema of the args, as listed by MCP tools/list
+}
+
+// CachePolicy allows runtimes to cache the results of a tool
+type CachePolicy struct {
+	TTL     int      `yaml:"ttl" json:"ttl"`                             // Time to live of cached results in seconds
+	KeyArgs []string `yaml:"keyArgs,omitempty" json:"keyArgs,omitempty"` // Arguments forming the cache key (default: all arguments)
 }
 
 // Arg represents an MCP tool argument
@@ -59,15 +142,31 @@ type Arg struct {
 	Required    bool   `yaml:"required,omitempty" json:"required,omitempty"`
 	Default     any    `yaml:"default,omitempty" json:"default,omitempty"`
 	Enum        []any  `yaml:"enum,omitempty" json:"enum,omitempty"`
+	Examples    []any  `yaml:"examples,omitempty" json:"examples,omitempty"`
+
+	// validation constraints
+	Format    string   `yaml:"format,omitempty" json:"format,omitempty"`
+	Pattern   string   `yaml:"pattern,omitempty" json:"pattern,omitempty"`
+	Minimum   *float64 `yaml:"minimum,omitempty" json:"minimum,omitempty"`
+	Maximum   *float64 `yaml:"maximum,omitempty" json:"maximum,omitempty"`
+	MinLength uint64   `yaml:"minLength,omitempty" json:"minLength,omitempty"`
+	MaxLength *uint64  `yaml:"maxLength,omitempty" json:"maxLength,omitempty"`
+
+	Nullable bool `yaml:"nullable,omitempty" json:"nullable,omitempty"`
 
 	// array specific
-	MinItems uint64  `json:"minItems,omitempty" yaml:"minItems,omitempty"`
-	MaxItems *uint64 `json:"maxItems,omitempty" yaml:"maxItems,omitempty"`
-	Items    *Arg    `yaml:"items,omitempty" json:"items,omitempty"`
+	MinItems    uint64  `json:"minItems,omitempty" yaml:"minItems,omitempty"`
+	MaxItems    *uint64 `json:"maxItems,omitempty" yaml:"maxItems,omitempty"`
+	UniqueItems bool    `yaml:"uniqueItems,omitempty" json:"uniqueItems,omitempty"`
+	Items       *Arg    `yaml:"items,omitempty" json:"items,omitempty"`
 
 	Properties map[string]Arg `yaml:"properties,omitempty" json:"properties,omitempty"`
-	Position   string         `yaml:"position,omitempty" json:"position,omitempty"`
-	Enabled    bool           `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+	// Ref names the schema in MCPConfig.Schemas defining the properties, instead of Properties
+	Ref string `yaml:"ref,omitempty" json:"ref,omitempty"`
+	// AdditionalProperties describes the values of map-shaped objects
+	AdditionalProperties *Arg   `yaml:"additionalProperties,omitempty" json:"additionalProperties,omitempty"`
+	Position             string `yaml:"position,omitempty" json:"position,omitempty"`
+	Enabled              bool   `yaml:"enabled,omitempty" json:"enabled,omitempty"`
 }
 
 // RequestTemplate represents the MCP request template
@@ -84,8 +183,9 @@ type RequestTemplate struct {
 
 // ToolSecurityRequirement specifies a security scheme requirement for a tool.
 type ToolSecurityRequirement struct {
-	ID          string `yaml:"id" json:"id"`                                       // References a SecurityScheme ID defined in ServerConfig.SecuritySchemes
-	Passthrough bool   `yaml:"passthrough,omitempty" json:"passthrough,omitempty"` // Whether to pass through the security credentials
+	ID          string   `yaml:"id" json:"id"`                                       // References a SecurityScheme ID defined in ServerConfig.SecuritySchemes
+	Scopes      []string `yaml:"scopes,omitempty" json:"scopes,omitempty"`           // Scopes required by the operation, e.g. for OAuth2 schemes
+	Passthrough bool     `yaml:"passthrough,omitempty" json:"passthrough,omitempty"` // Whether to pass through the security credentials
 }
 
 // Header represents an HTTP header
@@ -107,6 +207,98 @@ type ConvertOptions struct {
 	ServerConfig   map[string]interface{} `json:"serverConfig"`
 	ToolNamePrefix string                 `json:"toolNamePrefix"`
 	TemplatePath   string                 `json:"templatePath"`
+	// NameFrom derives the server name from the document info when ServerName is not set:
+	// "title" (default), "title-version" or a Go template such as "{{.Title}}-v{{.Version}}"
+	NameFrom string `json:"nameFrom"`
+	// CredentialFrom sets credential references of security schemes by scheme ID,
+	// replacing their default credential
+	CredentialFrom map[string]CredentialSource `json:"credentialFrom"`
+	// BaseURL overrides the URL of the servers of the document, e.g. when they are placeholders or internal URLs
+	BaseURL string `json:"baseURL"`
+	// PrefixByTag namespaces tool names with the first tag of their operation, e.g. "users.list"
+	PrefixByTag bool `json:"prefixByTag"`
+	// DescriptionFormat controls how HTML in descriptions is handled: "raw" (default), "markdown" or "text"
+	DescriptionFormat string `json:"descriptionFormat"`
+	// MaxDescriptionLength truncates tool descriptions to this many characters (0 means unlimited)
+	MaxDescriptionLength int `json:"maxDescriptionLength"`
+	// MaxArgDescriptionLength truncates argument descriptions to this many characters (0 means unlimited)
+	MaxArgDescriptionLength int `json:"maxArgDescriptionLength"`
+	// DescriptionSummary keeps only the summary or first sentence of descriptions
+	DescriptionSummary bool `json:"descriptionSummary"`
+	// DescriptionTemplate is a Go template for tool descriptions, e.g. "[{{.Method}} {{.Path}}] {{.Summary}}".
+	// It can use .Method, .Path, .OperationID, .Summary, .Description and .Tags.
+	DescriptionTemplate string `json:"descriptionTemplate"`
+	// Language selects localized descriptions from x-description-i18n/x-summary-i18n extensions (e.g. "zh-CN")
+	Language string `json:"language"`
+	// DeriveAnnotations adds readOnlyHint/destructiveHint/idempotentHint annotations based on the HTTP method
+	DeriveAnnotations bool `json:"deriveAnnotations"`
+	// ResponseMaxFields enables the description of the response structure prepended to responses, summarized
+	// to this many fields per level with repeated structures described once (0 disables the description)
+	ResponseMaxFields int `json:"responseMaxFields"`
+	// ResponseMaxDepth is the number of nesting levels of the response description (0 means 10)
+	ResponseMaxDepth int `json:"responseMaxDepth"`
+	// RateLimitInDescription appends the rate limit of x-ratelimit-* extensions to tool descriptions
+	RateLimitInDescription bool `json:"rateLimitInDescription"`
+	// EmitEvents describes the callbacks and webhooks of the document in an events section
+	EmitEvents bool `json:"emitEvents"`
+	// EmitMetadata adds a metadata block recording the generator version and conversion options
+	EmitMetadata bool `json:"emitMetadata"`
+	// GetAsResources exposes parameterless GET operations as MCP resources instead of tools
+	GetAsResources bool `json:"getAsResources"`
+	// ExamplesInDescription appends the examples of arguments to their descriptions
+	ExamplesInDescription bool `json:"examplesInDescription"`
+	// InferFormats derives formats and descriptions of string arguments named *_id, *_at or *_url when the spec omits them
+	InferFormats bool `json:"inferFormats"`
+	// EmitPrompts generates MCP prompts from the request examples of operations
+	EmitPrompts bool `json:"emitPrompts"`
+	// FoldConstants sets required path, query and header parameters allowing a single value (const or
+	// one-element enum) in the request template instead of exposing them as args
+	FoldConstants bool `json:"foldConstants"`
+	// FlattenBodyDepth flattens nested objects of JSON request bodies into args positioned at the
+	// dot-path of the property (e.g. "body.user.address.city"), down to this many levels (0 disables)
+	FlattenBodyDepth int `json:"flattenBodyDepth"`
+	// SharedSchemas defines object properties repeated across args once in the schemas section
+	SharedSchemas bool `json:"sharedSchemas"`
+	// EmitInputSchema adds the JSON Schema of the args of every tool as inputSchema, for MCP servers other than Higress
+	EmitInputSchema bool `json:"emitInputSchema"`
+	// Sort is the order of tools: "alpha" (default), "none" for the document order or "tag" to group them by tag
+	Sort string `json:"sort"`
+	// KeepArgOrder keeps parameters in declaration order, followed by the request body args, instead of sorting args by name
+	KeepArgOrder bool `json:"keepArgOrder"`
+	// Concurrency is the number of operations converted in parallel (0 means the number of CPUs)
+	Concurrency int `json:"concurrency"`
+	// FailOnWarnings lists warning categories that fail the conversion (e.g. "missing-description")
+	FailOnWarnings []string `json:"failOnWarnings"`
+	// WarningsAsErrors fails the conversion on any warning
+	WarningsAsErrors bool `json:"warningsAsErrors"`
+	// Filter selects the operations to convert
+	Filter Filter `json:"filter"`
+	// Passthrough selects the tools passing the credentials of their client through to the API
+	Passthrough Passthrough `json:"passthrough"`
+	// Profile names a set of default options, e.g. "compact" (see converter.Profiles)
+	Profile string `json:"profile"`
+}
+
+// Filter selects operations by tag, path or operation ID.
+// An operation is converted if it matches any include rule (or no include rules are set)
+// and matches no exclude rule. HEAD, OPTIONS and TRACE operations are only converted if their
+// method is listed in IncludeMethods or their operation ID in IncludeOperations.
+type Filter struct {
+	IncludeTags       []string `yaml:"includeTags,omitempty" json:"includeTags,omitempty"`
+	ExcludeTags       []string `yaml:"excludeTags,omitempty" json:"excludeTags,omitempty"`
+	IncludePaths      []string `yaml:"includePaths,omitempty" json:"includePaths,omitempty"` // Glob patterns, e.g. "/users/*"
+	ExcludePaths      []string `yaml:"excludePaths,omitempty" json:"excludePaths,omitempty"`
+	IncludeOperations []string `yaml:"includeOperations,omitempty" json:"includeOperations,omitempty"` // Operation IDs
+	ExcludeOperations []string `yaml:"excludeOperations,omitempty" json:"excludeOperations,omitempty"`
+	IncludeMethods    []string `yaml:"includeMethods,omitempty" json:"includeMethods,omitempty"` // HEAD, OPTIONS or TRACE, skipped otherwise
+}
+
+// Passthrough selects the tools whose security requirement passes the credentials of the MCP
+// client through to the API, such as bearer tokens, instead of using the default credential.
+// A tool is selected if its scheme or one of the tags of its operation is listed.
+type Passthrough struct {
+	Schemes []string `yaml:"schemes,omitempty" json:"schemes,omitempty"` // Security scheme IDs
+	Tags    []string `yaml:"tags,omitempty" json:"tags,omitempty"`
 }
 
 // ToolTemplate represents a template for applying to all tools
@@ -114,6 +306,8 @@ type ToolTemplate struct {
 	RequestTemplate  *RequestTemplate         `yaml:"requestTemplate,omitempty" json:"requestTemplate,omitempty"`
 	ResponseTemplate *ResponseTemplate        `yaml:"responseTemplate,omitempty" json:"responseTemplate,omitempty"`
 	Security         *ToolSecurityRequirement `yaml:"security,omitempty" json:"security,omitempty"`
+	Cache            *CachePolicy             `yaml:"cache,omitempty" json:"cache,omitempty"` // Applied to GET tools without a cache policy
+	Passthrough      *Passthrough             `yaml:"passthrough,omitempty" json:"passthrough,omitempty"`
 }
 
 // MCPConfigTemplate represents a template for patching the generated config
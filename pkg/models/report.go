@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// Report is the machine-readable report of a run of the converter, with a conversion report
+// for each input specification
+type Report struct {
+	Generator  GeneratorInfo `yaml:"generator" json:"generator"`
+	StartedAt  time.Time     `yaml:"startedAt" json:"startedAt"`
+	DurationMS int64         `yaml:"durationMs" json:"durationMs"`
+	Specs      []SpecReport  `yaml:"specs" json:"specs"`
+}
+
+// SpecReport is the report of the conversion of a single specification
+type SpecReport struct {
+	Input            string `yaml:"input" json:"input"`
+	Server           string `yaml:"server,omitempty" json:"server,omitempty"`
+	DurationMS       int64  `yaml:"durationMs" json:"durationMs"` // Time spent reading, parsing and converting the specification
+	ConversionReport `yaml:",inline"`
+}
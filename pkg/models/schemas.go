@@ -0,0 +1,51 @@
+package models
+
+// InlineSchemas returns a copy of the configuration in which the args referencing shared
+// schemas define their properties inline, for consumers that do not support the schemas section.
+// The configuration itself is not modified.
+func (c *MCPConfig) InlineSchemas() *MCPConfig {
+	inlined := *c
+	if len(c.Schemas) == 0 {
+		return &inlined
+	}
+	inlined.Schemas = nil
+	inlined.Tools = make([]Tool, len(c.Tools))
+	for i, tool := range c.Tools {
+		tool.Args = make([]Arg, len(c.Tools[i].Args))
+		for j, arg := range c.Tools[i].Args {
+			tool.Args[j] = inlineArg(arg, c.Schemas, 0)
+		}
+		inlined.Tools[i] = tool
+	}
+	return &inlined
+}
+
+// maxInlineDepth stops inlining schemas that reference themselves
+const maxInlineDepth = 32
+
+// inlineArg replaces the ref of an arg and its nested args with the properties of the schema
+func inlineArg(arg Arg, schemas map[string]Schema, depth int) Arg {
+	if depth >= maxInlineDepth {
+		return arg
+	}
+	properties := arg.Properties
+	if schema, ok := schemas[arg.Ref]; ok {
+		properties = schema.Properties
+		arg.Ref = ""
+	}
+	if properties != nil {
+		arg.Properties = make(map[string]Arg, len(properties))
+		for name, property := range properties {
+			arg.Properties[name] = inlineArg(property, schemas, depth+1)
+		}
+	}
+	if arg.Items != nil {
+		items := inlineArg(*arg.Items, schemas, depth+1)
+		arg.Items = &items
+	}
+	if arg.AdditionalProperties != nil {
+		values := inlineArg(*arg.AdditionalProperties, schemas, depth+1)
+		arg.AdditionalProperties = &values
+	}
+	return arg
+}
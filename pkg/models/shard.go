@@ -0,0 +1,15 @@
+package models
+
+// ShardIndex describes the configurations a server configuration was split into
+type ShardIndex struct {
+	Server            string       `yaml:"server" json:"server"`
+	MaxToolsPerConfig int          `yaml:"maxToolsPerConfig" json:"maxToolsPerConfig"`
+	Shards            []ShardEntry `yaml:"shards" json:"shards"`
+}
+
+// ShardEntry describes one configuration of a split server configuration
+type ShardEntry struct {
+	File  string   `yaml:"file" json:"file"`
+	Tags  []string `yaml:"tags,omitempty" json:"tags,omitempty"` // Tags of the tools, in order
+	Tools []string `yaml:"tools" json:"tools"`
+}
@@ -0,0 +1,21 @@
+package models
+
+// SmokeTests lists sample tools/call requests exercising the tools of a server configuration
+type SmokeTests struct {
+	Server string     `yaml:"server" json:"server"`
+	Tests  []ToolCall `yaml:"tests" json:"tests"`
+}
+
+// ToolCall is an MCP tools/call JSON-RPC request
+type ToolCall struct {
+	JSONRPC string         `yaml:"jsonrpc" json:"jsonrpc"`
+	ID      int            `yaml:"id" json:"id"`
+	Method  string         `yaml:"method" json:"method"`
+	Params  ToolCallParams `yaml:"params" json:"params"`
+}
+
+// ToolCallParams names the tool called and its arguments
+type ToolCallParams struct {
+	Name      string         `yaml:"name" json:"name"`
+	Arguments map[string]any `yaml:"arguments" json:"arguments"`
+}
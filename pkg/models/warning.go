@@ -0,0 +1,50 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Warning describes a non-fatal problem found while converting a specification
+type Warning struct {
+	Category string `yaml:"category" json:"category"`             // e.g. "lossy-schema", "missing-description", "name-collision"
+	Code     string `yaml:"code" json:"code"`                     // Precise problem within the category, e.g. "unsupported-content-type"
+	Tool     string `yaml:"tool,omitempty" json:"tool,omitempty"` // Name of the affected tool, if any
+	Message  string `yaml:"message" json:"message"`
+}
+
+// String formats a warning for display
+func (w Warning) String() string {
+	if w.Tool == "" {
+		return fmt.Sprintf("[%s] %s", w.Category, w.Message)
+	}
+	return fmt.Sprintf("[%s] %s: %s", w.Category, w.Tool, w.Message)
+}
+
+// SkippedOperation records an operation that was not converted
+type SkippedOperation struct {
+	Method      string `yaml:"method" json:"method"`
+	Path        string `yaml:"path" json:"path"`
+	OperationID string `yaml:"operationId" json:"operationId"`
+	Reason      string `yaml:"reason" json:"reason"` // e.g. "excluded by tag"
+}
+
+// String formats a skipped operation for display
+func (s SkippedOperation) String() string {
+	return fmt.Sprintf("%s %s (%s): %s", strings.ToUpper(s.Method), s.Path, s.OperationID, s.Reason)
+}
+
+// ToolSource records the operation a tool was converted from
+type ToolSource struct {
+	Tool        string `yaml:"tool" json:"tool"`
+	Method      string `yaml:"method,omitempty" json:"method,omitempty"`
+	Path        string `yaml:"path,omitempty" json:"path,omitempty"`
+	OperationID string `yaml:"operationId,omitempty" json:"operationId,omitempty"` // Empty for tools added by templates
+}
+
+// ConversionReport collects the diagnostics of a conversion
+type ConversionReport struct {
+	Tools    []ToolSource       `yaml:"tools" json:"tools"`
+	Warnings []Warning          `yaml:"warnings" json:"warnings"`
+	Skipped  []SkippedOperation `yaml:"skipped" json:"skipped"`
+}
@@ -0,0 +1,195 @@
+// Package output writes MCP configurations section by section, so that the
+// encoded form of a large configuration is never held in memory as a whole.
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+	"gopkg.in/yaml.v3"
+)
+
+// Supported output formats
+const (
+	FormatYAML = "yaml"
+	FormatJSON = "json"
+)
+
+// section is a top-level field of a configuration; list sections are encoded item by item
+type section struct {
+	key   string
+	value any
+	items []any
+}
+
+// WriteConfig encodes a configuration to w in the given format, producing the same
+// output as encoding the whole configuration at once with two-space indentation
+func WriteConfig(w io.Writer, config *models.MCPConfig, format string) error {
+	buffered := bufio.NewWriter(w)
+	var err error
+	switch format {
+	case FormatJSON:
+		err = writeJSON(buffered, sections(config))
+	case FormatYAML, "":
+		err = writeYAML(buffered, sections(config))
+	default:
+		return fmt.Errorf("unknown output format %q, expected %s or %s", format, FormatYAML, FormatJSON)
+	}
+	if err != nil {
+		return err
+	}
+	return buffered.Flush()
+}
+
+// sections lists the non-empty top-level fields of a configuration in declaration order
+func sections(config *models.MCPConfig) []section {
+	var result []section
+	if config.ToolSet != nil {
+		result = append(result, section{key: "toolSet", value: config.ToolSet})
+	}
+	result = append(result, section{key: "server", value: config.Server})
+	if len(config.Tools) > 0 {
+		result = append(result, section{key: "tools", items: listItems(config.Tools)})
+	}
+	if len(config.Resources) > 0 {
+		result = append(result, section{key: "resources", items: listItems(config.Resources)})
+	}
+	if len(config.Prompts) > 0 {
+		result = append(result, section{key: "prompts", items: listItems(config.Prompts)})
+	}
+	if len(config.Events) > 0 {
+		result = append(result, section{key: "events", items: listItems(config.Events)})
+	}
+	if len(config.Schemas) > 0 {
+		result = append(result, section{key: "schemas", value: config.Schemas})
+	}
+	if config.Metadata != nil {
+		result = append(result, section{key: "metadata", value: config.Metadata})
+	}
+	return result
+}
+
+// listItems returns pointers to the items of a list, avoiding copies of large items
+func listItems[T any](list []T) []any {
+	items := make([]any, len(list))
+	for i := range list {
+		items[i] = &list[i]
+	}
+	return items
+}
+
+// writeYAML writes the sections as a YAML mapping
+func writeYAML(w io.Writer, sections []section) error {
+	for _, s := range sections {
+		if s.items == nil {
+			data, err := encodeYAML(map[string]any{s.key: s.value})
+			if err != nil {
+				return err
+			}
+			if _, err := w.Write(data); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if _, err := fmt.Fprintf(w, "%s:\n", s.key); err != nil {
+			return err
+		}
+		for _, item := range s.items {
+			// A single-item sequence, indented to nest under the key
+			data, err := encodeYAML([]any{item})
+			if err != nil {
+				return err
+			}
+			if _, err := w.Write(indent(data, "  ")); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// encodeYAML encodes a value as a YAML document with two-space indentation
+func encodeYAML(value any) ([]byte, error) {
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(value); err != nil {
+		return nil, fmt.Errorf("failed to encode YAML: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode YAML: %w", err)
+	}
+	return buffer.Bytes(), nil
+}
+
+// indent prefixes every non-empty line of data
+func indent(data []byte, prefix string) []byte {
+	var buffer bytes.Buffer
+	for _, line := range bytes.SplitAfter(data, []byte("\n")) {
+		if len(line) > 0 && line[0] != '\n' {
+			buffer.WriteString(prefix)
+		}
+		buffer.Write(line)
+	}
+	return buffer.Bytes()
+}
+
+// writeJSON writes the sections as an indented JSON object
+func writeJSON(w io.Writer, sections []section) error {
+	if _, err := io.WriteString(w, "{"); err != nil {
+		return err
+	}
+	for i, s := range sections {
+		if i > 0 {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(w, "\n  %q: ", s.key); err != nil {
+			return err
+		}
+
+		if s.items == nil {
+			if err := writeJSONValue(w, s.value, "  "); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if _, err := io.WriteString(w, "["); err != nil {
+			return err
+		}
+		for j, item := range s.items {
+			separator := "\n    "
+			if j > 0 {
+				separator = ",\n    "
+			}
+			if _, err := io.WriteString(w, separator); err != nil {
+				return err
+			}
+			if err := writeJSONValue(w, item, "    "); err != nil {
+				return err
+			}
+		}
+		if _, err := io.WriteString(w, "\n  ]"); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(w, "\n}")
+	return err
+}
+
+// writeJSONValue writes a value indented to the given nesting prefix
+func writeJSONValue(w io.Writer, value any, prefix string) error {
+	data, err := json.MarshalIndent(value, prefix, "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	_, err = w.Write(data)
+	return err
+}
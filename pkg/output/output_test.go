@@ -0,0 +1,72 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/converter"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/parser"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestWriteConfigMatchesWholeEncoding(t *testing.T) {
+	inputFiles, err := filepath.Glob("../../test/*.json")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, inputFiles)
+
+	for _, inputFile := range inputFiles {
+		t.Run(filepath.Base(inputFile), func(t *testing.T) {
+			p := parser.NewParser()
+			if !assert.NoError(t, p.ParseFile(inputFile)) {
+				return
+			}
+			config, err := converter.NewConverter(p, models.ConvertOptions{
+				EmitPrompts:    true,
+				GetAsResources: true,
+				EmitMetadata:   true,
+				SharedSchemas:  true,
+			}).Convert()
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assertSameEncoding(t, config)
+		})
+	}
+}
+
+func TestWriteConfigToolSet(t *testing.T) {
+	config := &models.MCPConfig{
+		ToolSet: &models.ToolSetConfig{Name: "all"},
+		Server:  models.ServerConfig{Name: "server"},
+	}
+	assertSameEncoding(t, config)
+}
+
+func TestWriteConfigUnknownFormat(t *testing.T) {
+	var actual bytes.Buffer
+	err := WriteConfig(&actual, &models.MCPConfig{}, "toml")
+	assert.EqualError(t, err, `unknown output format "toml", expected yaml or json`)
+}
+
+// assertSameEncoding checks that writing a configuration section by section
+// produces the same output as encoding it at once
+func assertSameEncoding(t *testing.T, config *models.MCPConfig) {
+	var expectedYAML bytes.Buffer
+	encoder := yaml.NewEncoder(&expectedYAML)
+	encoder.SetIndent(2)
+	assert.NoError(t, encoder.Encode(config))
+	var actualYAML bytes.Buffer
+	assert.NoError(t, WriteConfig(&actualYAML, config, FormatYAML))
+	assert.Equal(t, expectedYAML.String(), actualYAML.String())
+
+	expectedJSON, err := json.MarshalIndent(config, "", "  ")
+	assert.NoError(t, err)
+	var actualJSON bytes.Buffer
+	assert.NoError(t, WriteConfig(&actualJSON, config, FormatJSON))
+	assert.Equal(t, string(expectedJSON), actualJSON.String())
+}
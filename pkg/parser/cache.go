@@ -0,0 +1,125 @@
+package parser
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultCacheTTL is how long downloaded documents are used before being downloaded again
+const DefaultCacheTTL = 24 * time.Hour
+
+// defaultClient downloads documents when the cache has no client
+var defaultClient = &http.Client{Timeout: 30 * time.Second}
+
+// Cache stores downloaded specifications and remote references on disk, so repeated conversions
+// don't download them again and keep working offline once the cache is warm
+type Cache struct {
+	Dir    string        // Directory of the cached documents; no caching if empty
+	TTL    time.Duration // Age after which documents are downloaded again (0 means DefaultCacheTTL)
+	Client *http.Client  // Client of the downloads (default: a client timing out after 30 seconds)
+}
+
+// DefaultCacheDir returns the cache directory of the current user, or "" if there is none
+func DefaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "openapi-to-mcp")
+}
+
+// IsURL reports whether a location is an HTTP or HTTPS URL rather than a file path
+func IsURL(location string) bool {
+	return strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://")
+}
+
+// Fetch downloads a document, or returns it from the cache while it is fresh. Expired documents
+// are still returned when the download fails, e.g. offline. A nil cache downloads every time.
+func (c *Cache) Fetch(url string) ([]byte, error) {
+	if c == nil || c.Dir == "" {
+		return c.download(url)
+	}
+
+	path := c.path(url)
+	info, statErr := os.Stat(path)
+	ttl := c.TTL
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+	if statErr == nil && time.Since(info.ModTime()) < ttl {
+		if data, err := os.ReadFile(path); err == nil {
+			return data, nil
+		}
+	}
+
+	data, err := c.download(url)
+	if err != nil {
+		if statErr == nil {
+			if stale, readErr := os.ReadFile(path); readErr == nil {
+				return stale, nil
+			}
+		}
+		return nil, err
+	}
+	// A cache that can't be written only costs a download next time
+	_ = c.store(path, data)
+	return data, nil
+}
+
+// path returns the file caching a URL
+func (c *Cache) path(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(c.Dir, hex.EncodeToString(sum[:]))
+}
+
+// store writes a document to the cache, replacing the cached file at once so concurrent
+// conversions never read a partial document
+func (c *Cache) store(path string, data []byte) error {
+	if err := os.MkdirAll(c.Dir, 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	file, err := os.CreateTemp(c.Dir, ".download-*")
+	if err != nil {
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+	if err := os.Rename(file.Name(), path); err != nil {
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+	return nil
+}
+
+// download gets a document over HTTP
+func (c *Cache) download(url string) ([]byte, error) {
+	client := defaultClient
+	if c != nil && c.Client != nil {
+		client = c.Client
+	}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %s: unexpected status %s", url, resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	return data, nil
+}
@@ -0,0 +1,112 @@
+package parser
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheFetch(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/spec.json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"openapi": "3.0.0"}`))
+	}))
+	url := server.URL + "/spec.json"
+
+	cache := &Cache{Dir: t.TempDir(), TTL: time.Hour}
+	data, err := cache.Fetch(url)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"openapi": "3.0.0"}`, string(data))
+
+	// Fresh documents are not downloaded again
+	data, err = cache.Fetch(url)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"openapi": "3.0.0"}`, string(data))
+	assert.Equal(t, 1, requests)
+
+	// Expired documents are downloaded again, or used as they are when the download fails
+	expired := time.Now().Add(-2 * time.Hour)
+	assert.NoError(t, os.Chtimes(cache.path(url), expired, expired))
+	_, err = cache.Fetch(url)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, requests)
+
+	assert.NoError(t, os.Chtimes(cache.path(url), expired, expired))
+	server.Close()
+	data, err = cache.Fetch(url)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"openapi": "3.0.0"}`, string(data))
+
+	_, err = cache.Fetch(server.URL + "/other.json")
+	assert.ErrorContains(t, err, "failed to download "+server.URL+"/other.json")
+}
+
+func TestCacheFetchErrors(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	dir := t.TempDir()
+	_, err := (&Cache{Dir: dir}).Fetch(server.URL + "/missing.json")
+	assert.EqualError(t, err, "failed to download "+server.URL+"/missing.json: unexpected status 404 Not Found")
+	entries, _ := os.ReadDir(dir)
+	assert.Empty(t, entries)
+
+	var cache *Cache
+	_, err = cache.Fetch(server.URL + "/missing.json")
+	assert.Error(t, err)
+}
+
+func TestCacheFetchUnwritable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"openapi": "3.0.0"}`))
+	}))
+	defer server.Close()
+
+	// The cache directory can't be created under a file
+	file := filepath.Join(t.TempDir(), "file")
+	assert.NoError(t, os.WriteFile(file, nil, 0644))
+	data, err := (&Cache{Dir: filepath.Join(file, "cache")}).Fetch(server.URL + "/spec.json")
+	if assert.NoError(t, err) {
+		assert.Equal(t, `{"openapi": "3.0.0"}`, string(data))
+	}
+}
+
+func TestParseRemoteReferences(t *testing.T) {
+	dir := t.TempDir()
+	schemas := `{"components": {"schemas": {"Pet": {"type": "object", "properties": {"name": {"type": "string"}}}}}}`
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "schemas.json"), []byte(schemas), 0644))
+	remote := httptest.NewServer(http.FileServer(http.Dir(dir)))
+	defer remote.Close()
+
+	spec := `{
+  "openapi": "3.0.0",
+  "info": {"title": "Pets", "version": "1.0.0"},
+  "paths": {
+    "/pets": {
+      "post": {
+        "requestBody": {"content": {"application/json": {"schema": {"$ref": "` + remote.URL + `/schemas.json#/components/schemas/Pet"}}}},
+        "responses": {"201": {"description": "Created"}}
+      }
+    }
+  }
+}`
+	p := NewParser()
+	p.SetCache(&Cache{Dir: t.TempDir()})
+	assert.Error(t, p.ParseFrom([]byte(spec), remote.URL+"/spec.json"), "external references are disabled by default")
+
+	p.SetExternalRefs(true)
+	if assert.NoError(t, p.ParseFrom([]byte(spec), remote.URL+"/spec.json")) {
+		schema := p.GetPaths()["/pets"].Post.RequestBody.Value.Content["application/json"].Schema.Value
+		assert.Contains(t, schema.Properties, "name")
+	}
+}
@@ -0,0 +1,59 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// httpMethods are the operation fields of a path item
+var httpMethods = map[string]bool{
+	"get": true, "put": true, "post": true, "delete": true,
+	"options": true, "head": true, "patch": true, "trace": true,
+}
+
+// SetPreserveOrder sets whether the parser records the order operations are declared in,
+// which the loaded document does not keep
+func (p *Parser) SetPreserveOrder(preserve bool) {
+	p.PreserveOrder = preserve
+}
+
+// OperationIndex returns the position of an operation in the document.
+// It returns false if the order was not recorded or the operation is unknown.
+func (p *Parser) OperationIndex(path, method string) (int, bool) {
+	index, ok := p.order[operationKey(path, method)]
+	return index, ok
+}
+
+// HasOperationOrder reports whether the order of operations was recorded
+func (p *Parser) HasOperationOrder() bool {
+	return p.order != nil
+}
+
+// operationKey identifies an operation by method and path
+func operationKey(path, method string) string {
+	return strings.ToLower(method) + " " + path
+}
+
+// operationOrder reads the position of each operation from a JSON or YAML document
+func operationOrder(data []byte) (map[string]int, error) {
+	var document struct {
+		Paths yaml.Node `yaml:"paths"`
+	}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("failed to read the order of operations: %w", err)
+	}
+
+	order := make(map[string]int)
+	paths := document.Paths.Content
+	for i := 0; i+1 < len(paths); i += 2 {
+		fields := paths[i+1].Content
+		for j := 0; j+1 < len(fields); j += 2 {
+			if method := strings.ToLower(fields[j].Value); httpMethods[method] {
+				order[operationKey(paths[i].Value, method)] = len(order)
+			}
+		}
+	}
+	return order, nil
+}
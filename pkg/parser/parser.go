@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
@@ -13,8 +15,11 @@ import (
 // Parser represents an OpenAPI parser
 type Parser struct {
 	doc              *openapi3.T
-	data             []byte
+	order            map[string]int // Position of each operation, when PreserveOrder is set
 	ValidateDocument bool
+	PreserveOrder    bool
+	ExternalRefs     bool   // Whether $ref references may point to other files and URLs
+	Cache            *Cache // Cache of the remote references; nil downloads them every time
 }
 
 // NewParser creates a new OpenAPI parser
@@ -29,6 +34,17 @@ func (p *Parser) SetValidation(validate bool) {
 	p.ValidateDocument = validate
 }
 
+// SetExternalRefs sets whether $ref references may point to other files and URLs. They are
+// disabled by default, as they let a document read any local file or URL.
+func (p *Parser) SetExternalRefs(allow bool) {
+	p.ExternalRefs = allow
+}
+
+// SetCache sets the cache of the remote references
+func (p *Parser) SetCache(cache *Cache) {
+	p.Cache = cache
+}
+
 // ParseFile parses an OpenAPI document from a file
 func (p *Parser) ParseFile(filePath string) error {
 	data, err := os.ReadFile(filePath)
@@ -36,19 +52,35 @@ func (p *Parser) ParseFile(filePath string) error {
 		return fmt.Errorf("failed to read OpenAPI file: %w", err)
 	}
 
-	return p.Parse(data)
+	return p.ParseFrom(data, filePath)
 }
 
 // Parse parses an OpenAPI document from bytes
 func (p *Parser) Parse(data []byte) error {
+	return p.ParseFrom(data, "")
+}
+
+// ParseFrom parses an OpenAPI document read from a file path or URL, against which
+// relative references to other documents are resolved
+func (p *Parser) ParseFrom(data []byte, location string) error {
 	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = p.ExternalRefs
+	loader.ReadFromURIFunc = p.readFromURI
 
-	// Try to parse as JSON first
+	// Parse the document (loader can handle both JSON and YAML)
 	var doc *openapi3.T
 	var err error
-
-	// Parse the document (loader can handle both JSON and YAML)
-	doc, err = loader.LoadFromData(data)
+	switch {
+	case location == "":
+		doc, err = loader.LoadFromData(data)
+	case IsURL(location):
+		var u *url.URL
+		if u, err = url.Parse(location); err == nil {
+			doc, err = loader.LoadFromDataWithPath(data, u)
+		}
+	default:
+		doc, err = loader.LoadFromDataWithPath(data, &url.URL{Path: filepath.ToSlash(location)})
+	}
 
 	if err != nil {
 		return fmt.Errorf("failed to parse OpenAPI document: %w", err)
@@ -62,13 +94,23 @@ func (p *Parser) Parse(data []byte) error {
 		}
 	}
 
-	p.data = data
+	p.order = nil
+	if p.PreserveOrder {
+		if p.order, err = operationOrder(data); err != nil {
+			return err
+		}
+	}
+
 	p.doc = doc
 	return nil
 }
 
-func (p *Parser) GetData() []byte {
-	return p.data
+// readFromURI reads the documents of external references, downloading remote ones through the cache
+func (p *Parser) readFromURI(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+	if location.Scheme == "http" || location.Scheme == "https" {
+		return p.Cache.Fetch(location.String())
+	}
+	return openapi3.ReadFromFile(loader, location)
 }
 
 // GetDocument returns the parsed OpenAPI document
@@ -100,6 +142,29 @@ func (p *Parser) GetInfo() *openapi3.Info {
 	return p.doc.Info
 }
 
+// GetWebhooks returns the webhooks of an OpenAPI 3.1 document, keyed by name. The loader keeps the
+// webhooks section as an extension, so it is decoded here and its references are resolved.
+func (p *Parser) GetWebhooks() (map[string]*openapi3.PathItem, error) {
+	if p.doc == nil || p.doc.Extensions["webhooks"] == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(p.doc.Extensions["webhooks"])
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode webhooks: %w", err)
+	}
+	var webhooks map[string]*openapi3.PathItem
+	if err := json.Unmarshal(data, &webhooks); err != nil {
+		return nil, fmt.Errorf("failed to parse webhooks: %w", err)
+	}
+
+	// Webhooks are resolved like the paths of a document sharing the components
+	doc := &openapi3.T{OpenAPI: p.doc.OpenAPI, Components: p.doc.Components, Paths: webhooks}
+	if err := openapi3.NewLoader().ResolveRefsIn(doc, nil); err != nil {
+		return nil, fmt.Errorf("failed to resolve webhooks: %w", err)
+	}
+	return webhooks, nil
+}
+
 // isJSON checks if the data is in JSON format
 func isJSON(data []byte) bool {
 	var js json.RawMessage
@@ -0,0 +1,216 @@
+// Package postman converts Postman Collection v2.1 files to OpenAPI documents,
+// so that they can be converted to MCP configurations like any specification.
+package postman
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// schemaPrefix starts the info.schema URL of Postman collections
+const schemaPrefix = "https://schema.getpostman.com/json/collection/"
+
+// Collection is a Postman collection
+type Collection struct {
+	Info     Info       `json:"info"`
+	Item     []Item     `json:"item"`
+	Variable []Variable `json:"variable"`
+	Auth     *Auth      `json:"auth"`
+}
+
+// Info describes a collection
+type Info struct {
+	Name        string      `json:"name"`
+	Description Description `json:"description"`
+	Schema      string      `json:"schema"`
+}
+
+// Item is a folder, when it has items, or a request
+type Item struct {
+	Name        string      `json:"name"`
+	Description Description `json:"description"`
+	Item        []Item      `json:"item"`
+	Request     *Request    `json:"request"`
+	Auth        *Auth       `json:"auth"`
+}
+
+// Request is a request of a collection
+type Request struct {
+	Method      string      `json:"method"`
+	URL         URL         `json:"url"`
+	Header      []KeyValue  `json:"header"`
+	Body        *Body       `json:"body"`
+	Auth        *Auth       `json:"auth"`
+	Description Description `json:"description"`
+}
+
+// URL is a request URL, given either as a string or as an object
+type URL struct {
+	Raw      string     `json:"raw"`
+	Protocol string     `json:"protocol"`
+	Host     []string   `json:"host"`
+	Port     string     `json:"port"`
+	Path     []string   `json:"path"`
+	Query    []KeyValue `json:"query"`
+	Variable []Variable `json:"variable"`
+}
+
+// UnmarshalJSON accepts URLs given as strings
+func (u *URL) UnmarshalJSON(data []byte) error {
+	var raw string
+	if json.Unmarshal(data, &raw) == nil {
+		*u = parseRawURL(raw)
+		return nil
+	}
+	type plain URL
+	var value plain
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*u = URL(value)
+	if len(u.Host) == 0 && len(u.Path) == 0 && u.Raw != "" {
+		parsed := parseRawURL(u.Raw)
+		u.Protocol, u.Host, u.Port, u.Path = parsed.Protocol, parsed.Host, parsed.Port, parsed.Path
+		if len(u.Query) == 0 {
+			u.Query = parsed.Query
+		}
+	}
+	return nil
+}
+
+// parseRawURL splits a raw URL such as {{baseUrl}}/users/:id?page=1 into its parts
+func parseRawURL(raw string) URL {
+	u := URL{Raw: raw}
+	rest := raw
+	if protocol, after, ok := strings.Cut(rest, "://"); ok {
+		u.Protocol, rest = protocol, after
+	}
+	rest, query, _ := strings.Cut(rest, "?")
+	for _, pair := range strings.Split(query, "&") {
+		if pair == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(pair, "=")
+		u.Query = append(u.Query, KeyValue{Key: key, Value: value})
+	}
+
+	host, path, _ := strings.Cut(rest, "/")
+	if hostname, port, ok := strings.Cut(host, ":"); ok && !strings.Contains(host, "{{") {
+		host, u.Port = hostname, port
+	}
+	if host != "" {
+		u.Host = strings.Split(host, ".")
+	}
+	if path != "" {
+		u.Path = strings.Split(path, "/")
+	}
+	return u
+}
+
+// KeyValue is a header, query parameter or form field
+type KeyValue struct {
+	Key         string      `json:"key"`
+	Value       string      `json:"value"`
+	Description Description `json:"description"`
+	Disabled    bool        `json:"disabled"`
+	Type        string      `json:"type"` // "text" or "file" for form data
+}
+
+// Variable is a collection or path variable
+type Variable struct {
+	Key         string      `json:"key"`
+	Value       any         `json:"value"`
+	Description Description `json:"description"`
+	Disabled    bool        `json:"disabled"`
+}
+
+// Body is a request body
+type Body struct {
+	Mode       string     `json:"mode"` // raw, urlencoded, formdata, file or graphql
+	Raw        string     `json:"raw"`
+	URLEncoded []KeyValue `json:"urlencoded"`
+	FormData   []KeyValue `json:"formdata"`
+	Options    struct {
+		Raw struct {
+			Language string `json:"language"`
+		} `json:"raw"`
+	} `json:"options"`
+}
+
+// Auth is the authentication of a collection, folder or request
+type Auth struct {
+	Type   string     `json:"type"` // e.g. noauth, bearer, basic or apikey
+	Bearer Attributes `json:"bearer"`
+	Basic  Attributes `json:"basic"`
+	APIKey Attributes `json:"apikey"`
+}
+
+// Attributes are the attributes of an auth type, given as a list in v2.1 and as an object in v2.0
+type Attributes []KeyValue
+
+// UnmarshalJSON accepts attributes given as objects
+func (a *Attributes) UnmarshalJSON(data []byte) error {
+	var list []KeyValue
+	if json.Unmarshal(data, &list) == nil {
+		*a = list
+		return nil
+	}
+	var object map[string]string
+	if err := json.Unmarshal(data, &object); err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	*a = make(Attributes, 0, len(keys))
+	for _, key := range keys {
+		*a = append(*a, KeyValue{Key: key, Value: object[key]})
+	}
+	return nil
+}
+
+// get returns the value of an attribute
+func (a Attributes) get(key string) string {
+	for _, attribute := range a {
+		if attribute.Key == key {
+			return attribute.Value
+		}
+	}
+	return ""
+}
+
+// Description is a description given either as a string or as an object with content
+type Description string
+
+// UnmarshalJSON accepts descriptions given as objects
+func (d *Description) UnmarshalJSON(data []byte) error {
+	var text string
+	if json.Unmarshal(data, &text) == nil {
+		*d = Description(text)
+		return nil
+	}
+	var object struct {
+		Content string `json:"content"`
+	}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return err
+	}
+	*d = Description(object.Content)
+	return nil
+}
+
+// IsCollection checks if data is a Postman collection
+func IsCollection(data []byte) bool {
+	var probe struct {
+		Info struct {
+			Schema string `json:"schema"`
+		} `json:"info"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return false
+	}
+	return strings.HasPrefix(probe.Info.Schema, schemaPrefix)
+}
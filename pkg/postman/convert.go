@@ -0,0 +1,597 @@
+package postman
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+var (
+	// variablePattern matches {{variable}} references
+	variablePattern = regexp.MustCompile(`{{\s*([^{}\s]+)\s*}}`)
+	// credentialPattern matches the names of headers and query parameters carrying credentials
+	credentialPattern = regexp.MustCompile(`(?i)^(proxy-)?authorization$|^cookie$|^key$|token|secret|passw|api[-_]?key|session|signature|credential`)
+)
+
+// Result is a collection converted to OpenAPI
+type Result struct {
+	// Document is the OpenAPI document in JSON
+	Document []byte
+	// Config holds the collection variables, to be used as the server config. The values of
+	// the variables referenced by credentials are left empty for the deployment to fill in.
+	Config map[string]any
+	// Warnings lists the requests that could not be converted as they are, e.g. requests merged
+	// into the operation of another request with the same method and path
+	Warnings []string
+}
+
+// Convert converts a Postman Collection v2.0 or v2.1 to an OpenAPI document.
+// Folders become tags, requests become operations and collection variables
+// become the server config; credentials referencing variables become
+// default credentials of the security schemes.
+func Convert(data []byte) (*Result, error) {
+	var collection Collection
+	if err := json.Unmarshal(data, &collection); err != nil {
+		return nil, fmt.Errorf("failed to parse Postman collection: %w", err)
+	}
+	if !strings.HasPrefix(collection.Info.Schema, schemaPrefix+"v2.") {
+		return nil, fmt.Errorf("unsupported Postman collection schema %q, expected v2.0 or v2.1", collection.Info.Schema)
+	}
+
+	c := &builder{
+		variables:    make(map[string]any),
+		schemes:      make(map[string]string),
+		operationIDs: make(map[string]int),
+		bases:        make(map[string]int),
+		doc: &openapi3.T{
+			OpenAPI: "3.0.0",
+			Info: &openapi3.Info{
+				Title:       collection.Info.Name,
+				Description: string(collection.Info.Description),
+				Version:     "1.0.0",
+			},
+			Paths:      openapi3.Paths{},
+			Components: &openapi3.Components{SecuritySchemes: openapi3.SecuritySchemes{}},
+		},
+	}
+	for _, variable := range collection.Variable {
+		if !variable.Disabled && variable.Key != "" {
+			c.variables[variable.Key] = variable.Value
+		}
+	}
+
+	c.convertItems(collection.Item, "", collection.Auth)
+	c.setServer()
+	c.clearCredentials()
+
+	document, err := json.Marshal(c.doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode OpenAPI document: %w", err)
+	}
+	return &Result{Document: document, Config: c.variables, Warnings: c.warnings}, nil
+}
+
+// builder holds the state of a collection conversion
+type builder struct {
+	doc          *openapi3.T
+	variables    map[string]any
+	schemes      map[string]string // Security scheme IDs by their definition
+	operationIDs map[string]int    // Number of uses of each operation ID
+	credentials  []string          // Variables referenced by credentials
+	bases        map[string]int    // Number of requests per base URL
+	firstBase    []string          // Base URLs in order of appearance
+	operations   []baseOperation
+	warnings     []string
+}
+
+// baseOperation remembers the base URL of an operation until the document server is chosen
+type baseOperation struct {
+	base      string
+	operation *openapi3.Operation
+}
+
+// convertItems converts the requests of a folder, which inherit its tag and authentication
+func (c *builder) convertItems(items []Item, tag string, auth *Auth) {
+	for _, item := range items {
+		itemAuth := auth
+		if item.Auth != nil {
+			itemAuth = item.Auth
+		}
+		if item.Request == nil {
+			c.addTag(item.Name, string(item.Description))
+			c.convertItems(item.Item, item.Name, itemAuth)
+			continue
+		}
+		c.convertRequest(item, tag, itemAuth)
+	}
+}
+
+// addTag declares the tag of a folder
+func (c *builder) addTag(name, description string) {
+	if c.doc.Tags.Get(name) == nil {
+		c.doc.Tags = append(c.doc.Tags, &openapi3.Tag{Name: name, Description: description})
+	}
+}
+
+// convertRequest converts a request to an operation
+func (c *builder) convertRequest(item Item, tag string, auth *Auth) {
+	request := item.Request
+	if request.Auth != nil {
+		auth = request.Auth
+	}
+	description := string(request.Description)
+	if description == "" {
+		description = string(item.Description)
+	}
+
+	operation := &openapi3.Operation{
+		OperationID: c.operationID(item.Name),
+		Summary:     item.Name,
+		Description: description,
+		Responses:   openapi3.Responses{"200": &openapi3.ResponseRef{Value: openapi3.NewResponse().WithDescription("Successful response")}},
+	}
+	if tag != "" {
+		operation.Tags = []string{tag}
+	}
+
+	path := c.convertPath(request.URL, operation)
+	c.convertQuery(request.URL.Query, operation)
+	skipHeaders := c.convertAuth(auth, operation)
+	contentType := c.convertHeaders(request.Header, skipHeaders, operation)
+	c.convertBody(request.Body, contentType, operation)
+
+	method := strings.ToUpper(request.Method)
+	if method == "" {
+		method = "GET"
+	}
+	pathItem := c.doc.Paths[path]
+	if pathItem == nil {
+		pathItem = &openapi3.PathItem{}
+		c.doc.Paths[path] = pathItem
+	}
+	// Collections often hold several requests for the same endpoint, e.g. a successful and a failing
+	// one, but an OpenAPI path has a single operation per method
+	if existing := pathItem.GetOperation(method); existing != nil {
+		mergeOperation(existing, operation)
+		c.warnings = append(c.warnings, fmt.Sprintf("request %q has the same method and path as %q (%s %s) and is merged into its operation %s",
+			item.Name, existing.Summary, method, path, existing.OperationID))
+		return
+	}
+	pathItem.SetOperation(method, operation)
+
+	base := c.baseURL(request.URL)
+	if c.bases[base] == 0 {
+		c.firstBase = append(c.firstBase, base)
+	}
+	c.bases[base]++
+	c.operations = append(c.operations, baseOperation{base: base, operation: operation})
+}
+
+// mergeOperation adds the parameters and body fields of another request for the same endpoint to an
+// operation, so that the args of both requests are available
+func mergeOperation(operation, other *openapi3.Operation) {
+	if operation.Description == "" {
+		operation.Description = other.Description
+	}
+	for _, param := range other.Parameters {
+		if operation.Parameters.GetByInAndName(param.Value.In, param.Value.Name) == nil {
+			operation.AddParameter(param.Value)
+		}
+	}
+	if other.RequestBody == nil {
+		return
+	}
+	if operation.RequestBody == nil {
+		operation.RequestBody = other.RequestBody
+		return
+	}
+	content := operation.RequestBody.Value.Content
+	for contentType, mediaType := range other.RequestBody.Value.Content {
+		existing, ok := content[contentType]
+		if !ok {
+			content[contentType] = mediaType
+			continue
+		}
+		if existing.Schema == nil || mediaType.Schema == nil || existing.Schema.Value.Properties == nil {
+			continue
+		}
+		for name, property := range mediaType.Schema.Value.Properties {
+			if _, ok := existing.Schema.Value.Properties[name]; !ok {
+				existing.Schema.Value.Properties[name] = property
+			}
+		}
+	}
+}
+
+// operationID derives a unique camelCase operation ID from a request name
+func (c *builder) operationID(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var id strings.Builder
+	for i, word := range words {
+		first, size := utf8.DecodeRuneInString(word)
+		if i == 0 {
+			id.WriteRune(unicode.ToLower(first))
+		} else {
+			id.WriteRune(unicode.ToUpper(first))
+		}
+		id.WriteString(word[size:])
+	}
+	result := id.String()
+	if result == "" {
+		result = "request"
+	}
+
+	c.operationIDs[result]++
+	if count := c.operationIDs[result]; count > 1 {
+		return result + strconv.Itoa(count)
+	}
+	return result
+}
+
+// convertPath converts the path of a URL, turning :name and {{name}} segments into path parameters
+func (c *builder) convertPath(url URL, operation *openapi3.Operation) string {
+	descriptions := make(map[string]Variable)
+	for _, variable := range url.Variable {
+		descriptions[variable.Key] = variable
+	}
+
+	segments := make([]string, 0, len(url.Path))
+	for _, segment := range url.Path {
+		name := ""
+		switch {
+		case strings.HasPrefix(segment, ":"):
+			name = segment[1:]
+		case variablePattern.FindString(segment) == segment && segment != "":
+			name = variablePattern.FindStringSubmatch(segment)[1]
+		}
+		if name == "" {
+			if segment != "" {
+				segments = append(segments, segment)
+			}
+			continue
+		}
+
+		segments = append(segments, "{"+name+"}")
+		param := openapi3.NewPathParameter(name).WithSchema(openapi3.NewStringSchema())
+		variable := descriptions[name]
+		param.Description = string(variable.Description)
+		if value, ok := variable.Value.(string); ok && value != "" && !strings.Contains(value, "{{") {
+			param.Example = value
+		} else if value, ok := c.variables[name]; ok {
+			param.Example = value
+		}
+		operation.AddParameter(param)
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+// convertQuery converts query parameters, using their values as examples
+func (c *builder) convertQuery(query []KeyValue, operation *openapi3.Operation) {
+	for _, kv := range query {
+		if kv.Disabled || kv.Key == "" {
+			continue
+		}
+		param := openapi3.NewQueryParameter(kv.Key).WithSchema(openapi3.NewStringSchema())
+		param.Description = string(kv.Description)
+		if example := c.example(kv); example != "" {
+			param.Example = example
+		}
+		operation.AddParameter(param)
+	}
+}
+
+// convertHeaders converts request headers to header parameters, except the content type
+// and the headers carrying credentials, and returns the content type
+func (c *builder) convertHeaders(headers []KeyValue, skip []string, operation *openapi3.Operation) string {
+	var contentType string
+	for _, kv := range headers {
+		if kv.Disabled || kv.Key == "" {
+			continue
+		}
+		if strings.EqualFold(kv.Key, "Content-Type") {
+			contentType = kv.Value
+			continue
+		}
+		if containsFold(skip, kv.Key) {
+			continue
+		}
+		param := openapi3.NewHeaderParameter(kv.Key).WithSchema(openapi3.NewStringSchema())
+		param.Description = string(kv.Description)
+		if example := c.example(kv); example != "" {
+			param.Example = example
+		}
+		operation.AddParameter(param)
+	}
+	return contentType
+}
+
+// example returns the example of a query parameter or header. Credentials, such as an
+// Authorization header, have none, and the variables they reference are cleared like those of auth.
+func (c *builder) example(kv KeyValue) string {
+	if credentialPattern.MatchString(kv.Key) {
+		for _, match := range variablePattern.FindAllStringSubmatch(kv.Value, -1) {
+			c.credentials = append(c.credentials, match[1])
+		}
+		return ""
+	}
+	return c.resolve(kv.Value)
+}
+
+// convertBody converts a request body, inferring the schema of JSON bodies from their content
+func (c *builder) convertBody(body *Body, contentType string, operation *openapi3.Operation) {
+	if body == nil {
+		return
+	}
+
+	var schema *openapi3.Schema
+	var example any
+	switch body.Mode {
+	case "raw":
+		if body.Raw == "" {
+			return
+		}
+		if contentType == "" {
+			contentType = rawContentType(body.Options.Raw.Language)
+		}
+		if value, ok := parseJSON(body.Raw); ok && strings.Contains(contentType, "json") {
+			schema, example = inferSchema(value), value
+		} else {
+			schema, example = openapi3.NewStringSchema(), body.Raw
+		}
+	case "urlencoded", "formdata":
+		fields := body.URLEncoded
+		contentType = "application/x-www-form-urlencoded"
+		if body.Mode == "formdata" {
+			fields, contentType = body.FormData, "multipart/form-data"
+		}
+		schema = openapi3.NewObjectSchema()
+		for _, field := range fields {
+			if field.Disabled || field.Key == "" {
+				continue
+			}
+			property := openapi3.NewStringSchema()
+			if field.Type == "file" {
+				property.Format = "binary"
+			} else if example := c.resolve(field.Value); example != "" {
+				property.Example = example
+			}
+			property.Description = string(field.Description)
+			schema.WithProperty(field.Key, property)
+		}
+		if len(schema.Properties) == 0 {
+			return
+		}
+	default:
+		return
+	}
+
+	mediaType := openapi3.NewMediaType().WithSchema(schema)
+	mediaType.Example = example
+	operation.RequestBody = &openapi3.RequestBodyRef{Value: openapi3.NewRequestBody().
+		WithContent(openapi3.Content{contentType: mediaType})}
+}
+
+// convertAuth sets the security requirement of an operation and returns the headers carrying the credentials
+func (c *builder) convertAuth(auth *Auth, operation *openapi3.Operation) []string {
+	if auth == nil {
+		return nil
+	}
+
+	var id string
+	var scheme *openapi3.SecurityScheme
+	var credential string
+	var headers []string
+	switch auth.Type {
+	case "noauth":
+		operation.Security = openapi3.NewSecurityRequirements()
+		return nil
+	case "bearer":
+		id, scheme = "bearerAuth", openapi3.NewJWTSecurityScheme()
+		scheme.BearerFormat = ""
+		credential = auth.Bearer.get("token")
+		headers = []string{"Authorization"}
+	case "basic":
+		id, scheme = "basicAuth", openapi3.NewSecurityScheme().WithType("http").WithScheme("basic")
+		credential = auth.Basic.get("username") + ":" + auth.Basic.get("password")
+		headers = []string{"Authorization"}
+	case "apikey":
+		in := auth.APIKey.get("in")
+		if in == "" {
+			in = "header"
+		}
+		name := auth.APIKey.get("key")
+		if name == "" {
+			name = "X-API-Key"
+		}
+		id, scheme = "apiKeyAuth", openapi3.NewSecurityScheme().WithType("apiKey").WithIn(in).WithName(name)
+		credential = auth.APIKey.get("value")
+		if in == "header" {
+			headers = []string{name}
+		}
+	default:
+		// Other authentication types such as OAuth 2.0 or AWS signatures are not converted
+		return nil
+	}
+
+	// Only credentials referencing variables are kept, so that secrets are not copied to the configuration
+	if variablePattern.MatchString(credential) {
+		for _, match := range variablePattern.FindAllStringSubmatch(credential, -1) {
+			c.credentials = append(c.credentials, match[1])
+		}
+		scheme.Extensions = map[string]any{
+			"x-mcp-default-credential": variablePattern.ReplaceAllString(credential, "{{.config.$1}}"),
+		}
+	}
+
+	// Schemes that differ, e.g. by API key name or credential, get distinct IDs
+	definition, _ := json.Marshal(scheme)
+	if existing, ok := c.schemes[string(definition)]; ok {
+		id = existing
+	} else {
+		for i := 2; c.doc.Components.SecuritySchemes[id] != nil; i++ {
+			id = strings.TrimRight(id, "0123456789") + strconv.Itoa(i)
+		}
+		c.schemes[string(definition)] = id
+		c.doc.Components.SecuritySchemes[id] = &openapi3.SecuritySchemeRef{Value: scheme}
+	}
+
+	operation.Security = openapi3.NewSecurityRequirements().With(openapi3.NewSecurityRequirement().Authenticate(id))
+	return headers
+}
+
+// clearCredentials empties the variables referenced by credentials, and the variables their
+// values refer to, so that secrets stored in the collection are not copied to the configuration
+func (c *builder) clearCredentials() {
+	for len(c.credentials) > 0 {
+		name := c.credentials[0]
+		c.credentials = c.credentials[1:]
+		value, ok := c.variables[name]
+		if !ok || value == "" {
+			continue
+		}
+		for _, match := range variablePattern.FindAllStringSubmatch(fmt.Sprint(value), -1) {
+			c.credentials = append(c.credentials, match[1])
+		}
+		c.variables[name] = ""
+	}
+}
+
+// baseURL returns the protocol, host and port of a URL, with variables resolved
+func (c *builder) baseURL(url URL) string {
+	base := strings.Join(url.Host, ".")
+	if url.Port != "" {
+		base += ":" + url.Port
+	}
+	if url.Protocol != "" {
+		base = url.Protocol + "://" + base
+	}
+	return strings.TrimSuffix(c.substitute(base), "/")
+}
+
+// setServer uses the most common base URL as the server of the document;
+// operations with other base URLs get their own server
+func (c *builder) setServer() {
+	if len(c.firstBase) == 0 {
+		return
+	}
+	server := c.firstBase[0]
+	for _, base := range c.firstBase {
+		if c.bases[base] > c.bases[server] {
+			server = base
+		}
+	}
+	c.doc.Servers = openapi3.Servers{{URL: server}}
+	for _, op := range c.operations {
+		if op.base != server {
+			op.operation.Servers = &openapi3.Servers{{URL: op.base}}
+		}
+	}
+}
+
+// substitute replaces variable references with their values, leaving unknown variables in place
+func (c *builder) substitute(text string) string {
+	for range 10 {
+		replaced := variablePattern.ReplaceAllStringFunc(text, func(reference string) string {
+			name := variablePattern.FindStringSubmatch(reference)[1]
+			if value, ok := c.variables[name]; ok {
+				return fmt.Sprint(value)
+			}
+			return reference
+		})
+		if replaced == text {
+			break
+		}
+		text = replaced
+	}
+	return text
+}
+
+// resolve substitutes variables in an example value, returning an empty string if some are unknown
+func (c *builder) resolve(value string) string {
+	value = c.substitute(value)
+	if variablePattern.MatchString(value) {
+		return ""
+	}
+	return value
+}
+
+// rawContentType maps the language of a raw body to a content type
+func rawContentType(language string) string {
+	switch language {
+	case "json", "":
+		return "application/json"
+	case "xml":
+		return "application/xml"
+	case "html":
+		return "text/html"
+	case "javascript":
+		return "application/javascript"
+	}
+	return "text/plain"
+}
+
+// parseJSON parses a raw JSON body, replacing unquoted variable references if needed
+func parseJSON(raw string) (any, bool) {
+	var value any
+	if json.Unmarshal([]byte(raw), &value) == nil {
+		return value, true
+	}
+	if json.Unmarshal([]byte(variablePattern.ReplaceAllString(raw, "null")), &value) == nil {
+		return value, true
+	}
+	return nil, false
+}
+
+// inferSchema infers a schema from a JSON example value
+func inferSchema(value any) *openapi3.Schema {
+	switch v := value.(type) {
+	case map[string]any:
+		schema := openapi3.NewObjectSchema()
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			schema.WithProperty(key, inferSchema(v[key]))
+		}
+		return schema
+	case []any:
+		schema := openapi3.NewArraySchema()
+		if len(v) > 0 {
+			schema.Items = openapi3.NewSchemaRef("", inferSchema(v[0]))
+		} else {
+			schema.Items = openapi3.NewSchemaRef("", openapi3.NewStringSchema())
+		}
+		return schema
+	case float64:
+		if v == float64(int64(v)) {
+			return openapi3.NewIntegerSchema()
+		}
+		return openapi3.NewFloat64Schema()
+	case bool:
+		return openapi3.NewBoolSchema()
+	case string:
+		return openapi3.NewStringSchema()
+	}
+	return &openapi3.Schema{}
+}
+
+// containsFold checks if a list contains a string, ignoring case
+func containsFold(list []string, value string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
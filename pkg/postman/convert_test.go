@@ -0,0 +1,181 @@
+package postman
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/converter"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/parser"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestConvertCollection(t *testing.T) {
+	const expectedOutput = "../../test/expected-postman-collection-mcp.yaml"
+
+	data, err := os.ReadFile("../../test/postman-collection.json")
+	assert.NoError(t, err)
+	assert.True(t, IsCollection(data))
+
+	result, err := Convert(data)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"baseUrl":     "https://api.bookstore.example.com/v1",
+		"accessToken": "",
+		"apiKey":      "",
+	}, result.Config)
+
+	p := parser.NewParser()
+	p.SetValidation(true)
+	assert.NoError(t, p.Parse(result.Document))
+
+	c := converter.NewConverter(p, models.ConvertOptions{ServerName: "bookstore", ServerConfig: result.Config})
+	config, err := c.Convert()
+	assert.NoError(t, err)
+
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+	assert.NoError(t, encoder.Encode(config))
+	actualYAML := buffer.Bytes()
+
+	// If the expected output file doesn't exist, write the actual output to it
+	if _, err := os.Stat(expectedOutput); os.IsNotExist(err) {
+		assert.NoError(t, os.WriteFile(expectedOutput, actualYAML, 0644))
+		t.Logf("Created expected output file: %s", expectedOutput)
+	}
+
+	expectedYAML, err := os.ReadFile(expectedOutput)
+	assert.NoError(t, err)
+	assert.Equal(t, string(expectedYAML), string(actualYAML))
+}
+
+func TestConvertRequests(t *testing.T) {
+	data := []byte(`{
+		"info": {"name": "Misc", "schema": "https://schema.getpostman.com/json/collection/v2.0.0/collection.json"},
+		"variable": [
+			{"key": "host", "value": "localhost"},
+			{"key": "user", "value": "{{adminUser}}"},
+			{"key": "adminUser", "value": "admin"},
+			{"key": "sessionToken", "value": "s3cr3t"}
+		],
+		"auth": {"type": "basic", "basic": {"username": "{{user}}", "password": "secret"}},
+		"item": [
+			{"name": "Get item", "request": {"method": "GET", "url": "http://{{host}}:8080/items/{{itemId}}"}},
+			{"name": "Get item", "request": {"method": "DELETE", "url": "http://{{host}}:8080/items/{{itemId}}"}},
+			{"name": "Ürün listesi", "request": {"method": "GET",
+				"url": {"raw": "http://{{host}}:8080/products?page=2&access_token={{sessionToken}}", "protocol": "http", "host": ["{{host}}"], "port": "8080", "path": ["products"],
+					"query": [{"key": "page", "value": "2"}, {"key": "access_token", "value": "{{sessionToken}}"}]},
+				"header": [{"key": "Accept", "value": "application/json"}, {"key": "Cookie", "value": "session=sk_live_SECRET"}]
+			}},
+			{"name": "Ping", "request": {
+				"url": "https://status.example.com/ping",
+				"auth": {"type": "bearer", "bearer": [{"key": "token", "value": "literal-token"}]}
+			}}
+		]
+	}`)
+
+	result, err := Convert(data)
+	assert.NoError(t, err)
+
+	doc, err := openapi3.NewLoader().LoadFromData(result.Document)
+	assert.NoError(t, err)
+
+	// The most common base URL becomes the server, other requests get their own
+	assert.Equal(t, "http://localhost:8080", doc.Servers[0].URL)
+	ping := doc.Paths["/ping"].Get
+	assert.Equal(t, "https://status.example.com", (*ping.Servers)[0].URL)
+
+	// Duplicate request names get unique operation IDs
+	item := doc.Paths["/items/{itemId}"]
+	assert.Equal(t, "getItem", item.Get.OperationID)
+	assert.Equal(t, "getItem2", item.Delete.OperationID)
+	assert.Equal(t, "itemId", item.Get.Parameters[0].Value.Name)
+	products := doc.Paths["/products"].Get
+	assert.Equal(t, "ürünListesi", products.OperationID)
+
+	// Headers and query parameters carrying credentials have no example
+	assert.Equal(t, "2", products.Parameters.GetByInAndName("query", "page").Example)
+	assert.Nil(t, products.Parameters.GetByInAndName("query", "access_token").Example)
+	assert.Equal(t, "application/json", products.Parameters.GetByInAndName("header", "Accept").Example)
+	assert.Nil(t, products.Parameters.GetByInAndName("header", "Cookie").Example)
+
+	// Credentials referencing variables become default credentials, literal ones are dropped
+	basic := doc.Components.SecuritySchemes["basicAuth"].Value
+	assert.Equal(t, "{{.config.user}}:secret", basic.Extensions["x-mcp-default-credential"])
+	bearer := doc.Components.SecuritySchemes["bearerAuth"].Value
+	assert.NotContains(t, bearer.Extensions, "x-mcp-default-credential")
+
+	// The variables of credentials are left empty
+	assert.Equal(t, map[string]any{"host": "localhost", "user": "", "adminUser": "", "sessionToken": ""}, result.Config)
+}
+
+func TestConvertRequestsWithSameEndpoint(t *testing.T) {
+	data := []byte(`{
+		"info": {"name": "Users", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+		"item": [
+			{"name": "Create user ok", "request": {"method": "POST", "url": "https://api.example.com/users",
+				"body": {"mode": "raw", "raw": "{\"name\": \"Ada\", \"age\": 36}"}}},
+			{"name": "Create user bad", "request": {"method": "POST", "url": "https://api.example.com/users?dryRun=true",
+				"body": {"mode": "raw", "raw": "{\"name\": \"\", \"email\": \"invalid\"}"}}}
+		]
+	}`)
+
+	result, err := Convert(data)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{`request "Create user bad" has the same method and path as "Create user ok" (POST /users) and is merged into its operation createUserOk`}, result.Warnings)
+
+	doc, err := openapi3.NewLoader().LoadFromData(result.Document)
+	assert.NoError(t, err)
+	operation := doc.Paths["/users"].Post
+	assert.Equal(t, "createUserOk", operation.OperationID)
+	assert.NotNil(t, operation.Parameters.GetByInAndName("query", "dryRun"))
+	schema := operation.RequestBody.Value.Content["application/json"].Schema.Value
+	assert.Len(t, schema.Properties, 3)
+}
+
+func TestConvertUnsupportedSchema(t *testing.T) {
+	_, err := Convert([]byte(`{"info": {"name": "Old", "schema": "https://schema.getpostman.com/json/collection/v1.0.0/collection.json"}}`))
+	assert.Error(t, err)
+}
+
+func TestIsCollection(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want bool
+	}{
+		{"collection", `{"info": {"schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"}}`, true},
+		{"openapi", `{"openapi": "3.0.0", "info": {"title": "API"}}`, false},
+		{"yaml", "openapi: 3.0.0\n", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsCollection([]byte(tt.data)))
+		})
+	}
+}
+
+func TestParseRawURL(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want URL
+	}{
+		{
+			raw:  "{{baseUrl}}/users/:id?page=1",
+			want: URL{Raw: "{{baseUrl}}/users/:id?page=1", Host: []string{"{{baseUrl}}"}, Path: []string{"users", ":id"}, Query: []KeyValue{{Key: "page", Value: "1"}}},
+		},
+		{
+			raw:  "http://localhost:8080/health",
+			want: URL{Raw: "http://localhost:8080/health", Protocol: "http", Host: []string{"localhost"}, Port: "8080", Path: []string{"health"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseRawURL(tt.raw))
+		})
+	}
+}
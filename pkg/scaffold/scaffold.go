@@ -0,0 +1,182 @@
+// Package scaffold creates starter files for converting an OpenAPI specification:
+// a template, a filter file and a project config listing the options the spec offers.
+package scaffold
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/converter"
+)
+
+//go:embed templates
+var templates embed.FS
+
+// Default names of the scaffolded files
+const (
+	TemplateFile = "mcp-template.yaml"
+	FilterFile   = "mcp-filter.yaml"
+	ProjectFile  = ".openapi-to-mcp.yaml"
+)
+
+// Options configures the scaffolded files
+type Options struct {
+	Input  string // Path of the OpenAPI specification, as written into the project config
+	Output string // Path of the MCP configuration to generate (default: <input name>-mcp.yaml)
+}
+
+// tag describes a tag of the spec
+type tag struct {
+	Name        string
+	Description string
+	Operations  int
+}
+
+// scaffoldData is the data passed to the templates
+type scaffoldData struct {
+	Title        string
+	ServerName   string
+	Input        string
+	Output       string
+	TemplateFile string
+	FilterFile   string
+	ProjectFile  string
+	Tags         []tag
+	Schemes      []*scheme
+	Profiles     []string
+}
+
+// scheme describes a security scheme of the spec
+type scheme struct {
+	ID     string
+	Type   string
+	Scheme string
+	In     string
+	Name   string
+}
+
+// Files renders the starter files for an OpenAPI document, keyed by file name
+func Files(doc *openapi3.T, options Options) (map[string][]byte, error) {
+	data := scaffoldData{
+		Title:        "the API",
+		Input:        options.Input,
+		Output:       options.Output,
+		TemplateFile: TemplateFile,
+		FilterFile:   FilterFile,
+		ProjectFile:  ProjectFile,
+		Tags:         collectTags(doc),
+		Schemes:      collectSchemes(doc),
+		Profiles:     converter.ProfileNames(),
+	}
+	if doc.Info != nil && doc.Info.Title != "" {
+		data.Title = doc.Info.Title
+	}
+	data.ServerName = serverName(data.Title)
+	if data.Output == "" {
+		data.Output = strings.TrimSuffix(filepath.Base(options.Input), filepath.Ext(options.Input)) + "-mcp.yaml"
+	}
+
+	files := make(map[string][]byte)
+	for name, tmpl := range map[string]string{
+		TemplateFile: "templates/template.yaml.tmpl",
+		FilterFile:   "templates/filter.yaml.tmpl",
+		ProjectFile:  "templates/project.yaml.tmpl",
+	} {
+		content, err := execute(tmpl, data)
+		if err != nil {
+			return nil, err
+		}
+		files[name] = content
+	}
+	return files, nil
+}
+
+// collectTags lists the tags used by operations, with the descriptions from the document's tag list
+func collectTags(doc *openapi3.T) []tag {
+	counts := make(map[string]int)
+	for _, pathItem := range doc.Paths {
+		for _, operation := range pathItem.Operations() {
+			for _, name := range operation.Tags {
+				counts[name]++
+			}
+		}
+	}
+
+	var tags []tag
+	seen := make(map[string]bool)
+	// Keep the order of the document's tag list, then add undeclared tags alphabetically
+	for _, t := range doc.Tags {
+		if t == nil || counts[t.Name] == 0 || seen[t.Name] {
+			continue
+		}
+		seen[t.Name] = true
+		tags = append(tags, tag{Name: t.Name, Description: firstLine(t.Description), Operations: counts[t.Name]})
+	}
+	var undeclared []string
+	for name := range counts {
+		if !seen[name] {
+			undeclared = append(undeclared, name)
+		}
+	}
+	sort.Strings(undeclared)
+	for _, name := range undeclared {
+		tags = append(tags, tag{Name: name, Operations: counts[name]})
+	}
+	return tags
+}
+
+// collectSchemes lists the security schemes of the document, sorted by ID
+func collectSchemes(doc *openapi3.T) []*scheme {
+	if doc.Components == nil {
+		return nil
+	}
+	var schemes []*scheme
+	for id, ref := range doc.Components.SecuritySchemes {
+		if ref == nil || ref.Value == nil {
+			continue
+		}
+		schemes = append(schemes, &scheme{
+			ID:     id,
+			Type:   ref.Value.Type,
+			Scheme: ref.Value.Scheme,
+			In:     ref.Value.In,
+			Name:   ref.Value.Name,
+		})
+	}
+	sort.Slice(schemes, func(i, j int) bool {
+		return schemes[i].ID < schemes[j].ID
+	})
+	return schemes
+}
+
+// firstLine returns the first line of a description
+func firstLine(s string) string {
+	return strings.TrimSpace(strings.SplitN(strings.TrimSpace(s), "\n", 2)[0])
+}
+
+// serverName derives a server name from the API title
+func serverName(title string) string {
+	return strings.Trim(strings.Join(strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	}), "-"), "-")
+}
+
+// execute renders an embedded template
+func execute(name string, data any) ([]byte, error) {
+	tmpl, err := template.New(filepath.Base(name)).Funcs(template.FuncMap{"join": strings.Join}).ParseFS(templates, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", name, err)
+	}
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, data); err != nil {
+		return nil, fmt.Errorf("failed to execute template %s: %w", name, err)
+	}
+	return buffer.Bytes(), nil
+}
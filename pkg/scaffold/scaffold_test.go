@@ -0,0 +1,68 @@
+package scaffold
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+	"github.com/higress-group/openapi-to-mcpserver/pkg/parser"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestFiles(t *testing.T) {
+	p := parser.NewParser()
+	assert.NoError(t, p.ParseFile("../../test/security-test.json"))
+
+	files, err := Files(p.GetDocument(), Options{Input: "specs/security-test.json"})
+	assert.NoError(t, err)
+	assert.Len(t, files, 3)
+
+	// Every scaffolded file must be valid YAML for the option it is used with
+	var template models.MCPConfigTemplate
+	assert.NoError(t, yaml.Unmarshal(files[TemplateFile], &template))
+	var filter models.Filter
+	assert.NoError(t, yaml.Unmarshal(files[FilterFile], &filter))
+	var project map[string]any
+	assert.NoError(t, yaml.Unmarshal(files[ProjectFile], &project))
+
+	assert.Equal(t, map[string]any{
+		"input":       "specs/security-test.json",
+		"output":      "security-test-mcp.yaml",
+		"template":    TemplateFile,
+		"filter-file": FilterFile,
+	}, project)
+	assert.Contains(t, string(files[TemplateFile]), "one of: ApiKeyHeaderAuth, ApiKeyQueryAuth, BasicAuth, BearerAuth")
+	assert.Contains(t, string(files[TemplateFile]), "#     scheme: bearer")
+
+	// Uncommenting the listed options yields a working configuration
+	uncommented := strings.ReplaceAll(string(files[TemplateFile]), "  # security:\n  #   id:", "  security:\n    id:")
+	assert.NoError(t, yaml.Unmarshal([]byte(uncommented), &template))
+	assert.Equal(t, "ApiKeyHeaderAuth", template.Tools.Security.ID)
+}
+
+func TestFilterFileListsTags(t *testing.T) {
+	p := parser.NewParser()
+	p.SetValidation(false)
+	assert.NoError(t, p.Parse([]byte(`{
+  "openapi": "3.0.0",
+  "info": {"title": "Tags", "version": "1.0.0"},
+  "tags": [
+    {"name": "users", "description": "User management\nwith details"},
+    {"name": "unused"}
+  ],
+  "paths": {
+    "/users": {"get": {"tags": ["users"]}, "post": {"tags": ["users", "admin"]}}
+  }
+}`)))
+
+	files, err := Files(p.GetDocument(), Options{Input: "tags.yaml"})
+	assert.NoError(t, err)
+	content := string(files[FilterFile])
+	assert.Contains(t, content, "#   - users  # User management, 2 operations\n#   - admin  # 1 operation\n")
+	assert.NotContains(t, content, "unused")
+
+	var filter models.Filter
+	assert.NoError(t, yaml.Unmarshal([]byte(strings.ReplaceAll(content, "\n# includeTags:\n#   - users", "\nincludeTags:\n  - users")), &filter))
+	assert.Equal(t, []string{"users"}, filter.IncludeTags)
+}
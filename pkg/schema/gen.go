@@ -0,0 +1,22 @@
+//go:build ignore
+
+// gen regenerates mcp-config.schema.json from the models package.
+// Run it with `go generate ./pkg/schema`.
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/schema"
+)
+
+func main() {
+	data, err := schema.Generate("../models")
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("mcp-config.schema.json", data, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
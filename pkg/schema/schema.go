@@ -0,0 +1,244 @@
+// Package schema provides a JSON Schema describing the MCP configuration format,
+// so editors can offer completion and validation for generated configs.
+package schema
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/models"
+)
+
+//go:generate go run gen.go
+
+// ID is the identifier of the MCP configuration schema
+const ID = "https://github.com/higress-group/openapi-to-mcpserver/mcp-config.schema.json"
+
+//go:embed mcp-config.schema.json
+var mcpConfigSchema []byte
+
+// requiredFields lists the fields that must be present, keyed by Go type name.
+// Arg is not listed since nested item and property args have no name.
+var requiredFields = map[string][]string{
+	"ServerConfig":            {"name"},
+	"SecurityScheme":          {"id", "type"},
+	"Tool":                    {"name"},
+	"RequestTemplate":         {"url", "method"},
+	"ToolSecurityRequirement": {"id"},
+	"Header":                  {"key", "value"},
+	"Resource":                {"uri", "name"},
+	"CachePolicy":             {"ttl"},
+	"Prompt":                  {"name", "messages"},
+	"PromptArgument":          {"name"},
+	"PromptMessage":           {"role", "content"},
+}
+
+// MCPConfig returns the embedded JSON Schema of the MCP configuration format
+func MCPConfig() []byte {
+	return mcpConfigSchema
+}
+
+// Generate builds the JSON Schema of models.MCPConfig.
+// Descriptions are taken from the doc comments of the Go sources in modelsDir.
+func Generate(modelsDir string) ([]byte, error) {
+	docs, err := parseDocs(modelsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &generator{docs: docs, definitions: make(map[string]any)}
+	root := reflect.TypeOf(models.MCPConfig{})
+	doc := map[string]any{
+		"$schema":     "http://json-schema.org/draft-07/schema#",
+		"$id":         ID,
+		"title":       "MCP server configuration",
+		"description": docs[root.Name()],
+		"$ref":        g.ref(root)["$ref"],
+		"definitions": g.definitions,
+	}
+
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(doc); err != nil {
+		return nil, fmt.Errorf("failed to encode schema: %w", err)
+	}
+	return buffer.Bytes(), nil
+}
+
+// generator converts Go types to JSON Schema definitions
+type generator struct {
+	docs        map[string]string
+	definitions map[string]any
+}
+
+// ref returns a reference to the definition of a struct type, generating it on first use
+func (g *generator) ref(t reflect.Type) map[string]any {
+	name := t.Name()
+	if _, ok := g.definitions[name]; !ok {
+		// Reserve the name first so recursive types terminate
+		g.definitions[name] = nil
+		g.definitions[name] = g.object(t)
+	}
+	return map[string]any{"$ref": "#/definitions/" + name}
+}
+
+// object builds the schema of a struct type
+func (g *generator) object(t reflect.Type) map[string]any {
+	properties := make(map[string]any)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := fieldName(field)
+		if name == "" {
+			continue
+		}
+		property := g.schema(field.Type)
+		if doc := g.docs[t.Name()+"."+field.Name]; doc != "" {
+			property = withDescription(property, doc)
+		}
+		properties[name] = property
+	}
+
+	object := map[string]any{
+		"type":       "object",
+		"properties": properties,
+	}
+	if doc := g.docs[t.Name()]; doc != "" {
+		object["description"] = doc
+	}
+	if required := requiredFields[t.Name()]; len(required) > 0 {
+		object["required"] = required
+	}
+	return object
+}
+
+// schema builds the schema of an arbitrary Go type
+func (g *generator) schema(t reflect.Type) map[string]any {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return g.schema(t.Elem())
+	case reflect.Struct:
+		return g.ref(t)
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": g.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": g.schema(t.Elem())}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]any{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	default:
+		// interface values accept anything
+		return map[string]any{}
+	}
+}
+
+// withDescription adds a description to a schema; references are wrapped since draft-07 ignores siblings of $ref
+func withDescription(schema map[string]any, description string) map[string]any {
+	if _, ok := schema["$ref"]; ok {
+		return map[string]any{"description": description, "allOf": []any{schema}}
+	}
+	schema["description"] = description
+	return schema
+}
+
+// fieldName returns the YAML name of a struct field, falling back to its JSON name
+func fieldName(field reflect.StructField) string {
+	if !field.IsExported() {
+		return ""
+	}
+	for _, key := range []string{"yaml", "json"} {
+		if tag, ok := field.Tag.Lookup(key); ok {
+			name := strings.Split(tag, ",")[0]
+			if name == "-" {
+				return ""
+			}
+			if name != "" {
+				return name
+			}
+		}
+	}
+	return field.Name
+}
+
+// parseDocs collects doc comments of the types and fields declared in a Go source directory.
+// Type docs are keyed by type name and field docs by "Type.Field".
+func parseDocs(dir string) (map[string]string, error) {
+	docs := make(map[string]string)
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		src, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		f, err := parser.ParseFile(fset, file, src, parser.ParseComments)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+		for _, decl := range f.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				structType, ok := typeSpec.Type.(*ast.StructType)
+				if !ok {
+					continue
+				}
+				docs[typeSpec.Name.Name] = cleanDoc(genDecl.Doc.Text())
+				for _, field := range structType.Fields.List {
+					doc := field.Doc.Text()
+					if isSectionComment(doc) {
+						doc = ""
+					}
+					if doc == "" {
+						doc = field.Comment.Text()
+					}
+					for _, name := range field.Names {
+						if doc := cleanDoc(doc); doc != "" {
+							docs[typeSpec.Name.Name+"."+name.Name] = doc
+						}
+					}
+				}
+			}
+		}
+	}
+	return docs, nil
+}
+
+// isSectionComment checks if a leading comment labels a group of fields (e.g. "// array specific")
+// rather than documenting the field that follows it
+func isSectionComment(doc string) bool {
+	return doc != "" && unicode.IsLower([]rune(doc)[0])
+}
+
+// cleanDoc joins a comment into a single line
+func cleanDoc(doc string) string {
+	return strings.Join(strings.Fields(doc), " ")
+}
@@ -0,0 +1,27 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmbeddedSchemaUpToDate(t *testing.T) {
+	generated, err := Generate("../models")
+	assert.NoError(t, err)
+	assert.Equal(t, string(generated), string(MCPConfig()), "mcp-config.schema.json is stale, run `go generate ./pkg/schema`")
+}
+
+func TestSchemaDefinitions(t *testing.T) {
+	var doc struct {
+		Ref         string                    `json:"$ref"`
+		Definitions map[string]map[string]any `json:"definitions"`
+	}
+	assert.NoError(t, json.Unmarshal(MCPConfig(), &doc))
+	assert.Equal(t, "#/definitions/MCPConfig", doc.Ref)
+	for _, name := range []string{"MCPConfig", "ServerConfig", "SecurityScheme", "Tool", "Arg", "RequestTemplate", "ResponseTemplate"} {
+		assert.Contains(t, doc.Definitions, name)
+	}
+	assert.Equal(t, []any{"name"}, doc.Definitions["Tool"]["required"])
+}
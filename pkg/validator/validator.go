@@ -0,0 +1,363 @@
+// Package validator checks MCP configuration files against the MCP configuration JSON Schema
+// and a set of semantic rules, reporting problems with their position in the file.
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/higress-group/openapi-to-mcpserver/pkg/schema"
+)
+
+// Severity levels, matching the LSP DiagnosticSeverity values
+const (
+	SeverityError   = 1
+	SeverityWarning = 2
+)
+
+// argPositions are the valid values of an argument position
+var argPositions = []string{"path", "query", "header", "cookie", "body"}
+
+// isBodyPath reports whether a position is the dot-path of a nested body property, e.g. body.user.name
+func isBodyPath(position string) bool {
+	path, ok := strings.CutPrefix(position, "body.")
+	return ok && !slices.Contains(strings.Split(path, "."), "")
+}
+
+// credentialSources are the kinds of credential references of a security scheme
+var credentialSources = []string{"env", "file", "secret"}
+
+var pathParamPattern = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// Diagnostic describes a problem found in a configuration file.
+// Line and Column are zero-based.
+type Diagnostic struct {
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Severity int    `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// String formats a diagnostic with one-based positions
+func (d Diagnostic) String() string {
+	severity := "error"
+	if d.Severity == SeverityWarning {
+		severity = "warning"
+	}
+	return fmt.Sprintf("%d:%d: %s: %s", d.Line+1, d.Column+1, severity, d.Message)
+}
+
+// Validator validates MCP configuration files
+type Validator struct {
+	definitions map[string]map[string]any
+	root        map[string]any
+}
+
+// New creates a validator using the embedded MCP configuration schema
+func New() (*Validator, error) {
+	var doc struct {
+		Ref         string                    `json:"$ref"`
+		Definitions map[string]map[string]any `json:"definitions"`
+	}
+	if err := json.Unmarshal(schema.MCPConfig(), &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse MCP configuration schema: %w", err)
+	}
+	return &Validator{
+		definitions: doc.Definitions,
+		root:        map[string]any{"$ref": doc.Ref},
+	}, nil
+}
+
+// Validate checks a YAML or JSON MCP configuration and returns the problems found, sorted by position
+func (v *Validator) Validate(data []byte) []Diagnostic {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return []Diagnostic{syntaxDiagnostic(err)}
+	}
+	if len(doc.Content) == 0 {
+		return nil
+	}
+
+	root := doc.Content[0]
+	var diagnostics []Diagnostic
+	v.validateNode(root, v.root, &diagnostics)
+	diagnostics = append(diagnostics, semanticChecks(root)...)
+
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		if diagnostics[i].Line != diagnostics[j].Line {
+			return diagnostics[i].Line < diagnostics[j].Line
+		}
+		return diagnostics[i].Column < diagnostics[j].Column
+	})
+	return diagnostics
+}
+
+// validateNode checks a YAML node against a schema
+func (v *Validator) validateNode(node *yaml.Node, s map[string]any, diagnostics *[]Diagnostic) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if ref, ok := s["$ref"].(string); ok {
+		s = v.definitions[strings.TrimPrefix(ref, "#/definitions/")]
+	}
+	if allOf, ok := s["allOf"].([]any); ok {
+		for _, sub := range allOf {
+			if sub, ok := sub.(map[string]any); ok {
+				v.validateNode(node, sub, diagnostics)
+			}
+		}
+	}
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!null" {
+		return
+	}
+
+	typ, _ := s["type"].(string)
+	if typ != "" && !matchesType(node, typ) {
+		*diagnostics = append(*diagnostics, errorAt(node, "expected %s, got %s", typ, describeNode(node)))
+		return
+	}
+
+	switch typ {
+	case "object":
+		properties, _ := s["properties"].(map[string]any)
+		additional, _ := s["additionalProperties"].(map[string]any)
+		seen := make(map[string]bool)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			seen[key.Value] = true
+			if property, ok := properties[key.Value].(map[string]any); ok {
+				v.validateNode(value, property, diagnostics)
+			} else if additional != nil {
+				v.validateNode(value, additional, diagnostics)
+			} else if properties != nil {
+				*diagnostics = append(*diagnostics, warningAt(key, "unknown property %q", key.Value))
+			}
+		}
+		if required, ok := s["required"].([]any); ok {
+			for _, name := range required {
+				if name, ok := name.(string); ok && !seen[name] {
+					*diagnostics = append(*diagnostics, errorAt(node, "missing required property %q", name))
+				}
+			}
+		}
+	case "array":
+		if items, ok := s["items"].(map[string]any); ok {
+			for _, item := range node.Content {
+				v.validateNode(item, items, diagnostics)
+			}
+		}
+	case "integer", "number":
+		if minimum, ok := s["minimum"].(float64); ok {
+			if value, err := strconv.ParseFloat(node.Value, 64); err == nil && value < minimum {
+				*diagnostics = append(*diagnostics, errorAt(node, "value must be at least %v", minimum))
+			}
+		}
+	}
+}
+
+// matchesType checks if a YAML node is compatible with a JSON Schema type
+func matchesType(node *yaml.Node, typ string) bool {
+	switch typ {
+	case "object":
+		return node.Kind == yaml.MappingNode
+	case "array":
+		return node.Kind == yaml.SequenceNode
+	case "string":
+		// YAML decoding turns any scalar into a string
+		return node.Kind == yaml.ScalarNode
+	case "boolean":
+		return node.Kind == yaml.ScalarNode && node.Tag == "!!bool"
+	case "integer":
+		return node.Kind == yaml.ScalarNode && node.Tag == "!!int"
+	case "number":
+		return node.Kind == yaml.ScalarNode && (node.Tag == "!!int" || node.Tag == "!!float")
+	}
+	return true
+}
+
+// describeNode names the kind of value held by a node for error messages
+func describeNode(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "object"
+	case yaml.SequenceNode:
+		return "array"
+	}
+	switch node.Tag {
+	case "!!bool":
+		return "boolean"
+	case "!!int":
+		return "integer"
+	case "!!float":
+		return "number"
+	}
+	return "string"
+}
+
+// semanticChecks reports problems the schema cannot express:
+// duplicate tool names, unnamed tool arguments, references to undefined security schemes, ambiguous credential references,
+// invalid argument positions and path placeholders without a matching argument
+func semanticChecks(root *yaml.Node) []Diagnostic {
+	var diagnostics []Diagnostic
+
+	schemeIDs := make(map[string]bool)
+	if server := mappingValue(root, "server"); server != nil {
+		if schemes := mappingValue(server, "securitySchemes"); schemes != nil && schemes.Kind == yaml.SequenceNode {
+			for _, scheme := range schemes.Content {
+				if id := mappingValue(scheme, "id"); id != nil {
+					if schemeIDs[id.Value] {
+						diagnostics = append(diagnostics, errorAt(id, "duplicate security scheme id %q", id.Value))
+					}
+					schemeIDs[id.Value] = true
+				}
+				if from := mappingValue(scheme, "credentialFrom"); from != nil && from.Kind == yaml.MappingNode && len(from.Content) != 2 {
+					diagnostics = append(diagnostics, errorAt(from, "credentialFrom must set exactly one of %s", strings.Join(credentialSources, ", ")))
+				}
+			}
+		}
+	}
+
+	schemaNames := make(map[string]bool)
+	if schemas := mappingValue(root, "schemas"); schemas != nil && schemas.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(schemas.Content); i += 2 {
+			schemaNames[schemas.Content[i].Value] = true
+		}
+		for i := 0; i+1 < len(schemas.Content); i += 2 {
+			diagnostics = checkRefs(mappingValue(schemas.Content[i+1], "properties"), schemaNames, diagnostics)
+		}
+	}
+
+	tools := mappingValue(root, "tools")
+	if tools == nil || tools.Kind != yaml.SequenceNode {
+		return diagnostics
+	}
+
+	toolNames := make(map[string]bool)
+	for _, tool := range tools.Content {
+		if tool.Kind != yaml.MappingNode {
+			continue
+		}
+		if name := mappingValue(tool, "name"); name != nil {
+			if toolNames[name.Value] {
+				diagnostics = append(diagnostics, errorAt(name, "duplicate tool name %q", name.Value))
+			}
+			toolNames[name.Value] = true
+		}
+
+		requestTemplate := mappingValue(tool, "requestTemplate")
+		for _, owner := range []*yaml.Node{tool, requestTemplate} {
+			if security := mappingValue(owner, "security"); security != nil {
+				if id := mappingValue(security, "id"); id != nil && !schemeIDs[id.Value] {
+					diagnostics = append(diagnostics, errorAt(id, "security scheme %q is not defined in server.securitySchemes", id.Value))
+				}
+			}
+		}
+
+		pathArgs := make(map[string]bool)
+		if args := mappingValue(tool, "args"); args != nil && args.Kind == yaml.SequenceNode {
+			for _, arg := range args.Content {
+				if arg.Kind == yaml.MappingNode && mappingValue(arg, "name") == nil {
+					diagnostics = append(diagnostics, errorAt(arg, "missing required property %q", "name"))
+				}
+				diagnostics = checkArgRefs(arg, schemaNames, diagnostics)
+				position := mappingValue(arg, "position")
+				if position == nil {
+					continue
+				}
+				if !slices.Contains(argPositions, position.Value) && !isBodyPath(position.Value) {
+					diagnostics = append(diagnostics, errorAt(position, "invalid argument position %q, expected one of %s", position.Value, strings.Join(argPositions, ", ")))
+				}
+				if name := mappingValue(arg, "name"); name != nil && position.Value == "path" {
+					pathArgs[name.Value] = true
+				}
+			}
+		}
+
+		if url := mappingValue(requestTemplate, "url"); url != nil {
+			for _, match := range pathParamPattern.FindAllStringSubmatch(url.Value, -1) {
+				// Template expressions like {{.config.apiKey}} are not path parameters
+				if strings.HasPrefix(match[1], "{") || strings.HasPrefix(match[1], ".") {
+					continue
+				}
+				if !pathArgs[match[1]] {
+					diagnostics = append(diagnostics, warningAt(url, "path parameter {%s} has no argument with position path", match[1]))
+				}
+			}
+		}
+	}
+
+	return diagnostics
+}
+
+// checkArgRefs reports the refs of an arg and its nested args to schemas that are not defined
+func checkArgRefs(arg *yaml.Node, schemaNames map[string]bool, diagnostics []Diagnostic) []Diagnostic {
+	if arg == nil || arg.Kind != yaml.MappingNode {
+		return diagnostics
+	}
+	if ref := mappingValue(arg, "ref"); ref != nil && !schemaNames[ref.Value] {
+		diagnostics = append(diagnostics, errorAt(ref, "schema %q is not defined in schemas", ref.Value))
+	}
+	diagnostics = checkRefs(mappingValue(arg, "properties"), schemaNames, diagnostics)
+	diagnostics = checkArgRefs(mappingValue(arg, "items"), schemaNames, diagnostics)
+	return checkArgRefs(mappingValue(arg, "additionalProperties"), schemaNames, diagnostics)
+}
+
+// checkRefs checks the refs of the args in a properties mapping
+func checkRefs(properties *yaml.Node, schemaNames map[string]bool, diagnostics []Diagnostic) []Diagnostic {
+	if properties == nil || properties.Kind != yaml.MappingNode {
+		return diagnostics
+	}
+	for i := 1; i < len(properties.Content); i += 2 {
+		diagnostics = checkArgRefs(properties.Content[i], schemaNames, diagnostics)
+	}
+	return diagnostics
+}
+
+// mappingValue returns the value of a key in a mapping node, or nil
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+var yamlLinePattern = regexp.MustCompile(`line (\d+)`)
+
+// syntaxDiagnostic converts a YAML parse error to a diagnostic
+func syntaxDiagnostic(err error) Diagnostic {
+	line := 0
+	if match := yamlLinePattern.FindStringSubmatch(err.Error()); match != nil {
+		line, _ = strconv.Atoi(match[1])
+		line--
+	}
+	return Diagnostic{Line: line, Severity: SeverityError, Message: err.Error()}
+}
+
+func errorAt(node *yaml.Node, format string, args ...any) Diagnostic {
+	return diagnosticAt(node, SeverityError, format, args...)
+}
+
+func warningAt(node *yaml.Node, format string, args ...any) Diagnostic {
+	return diagnosticAt(node, SeverityWarning, format, args...)
+}
+
+func diagnosticAt(node *yaml.Node, severity int, format string, args ...any) Diagnostic {
+	return Diagnostic{
+		Line:     max(node.Line-1, 0),
+		Column:   max(node.Column-1, 0),
+		Severity: severity,
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
@@ -0,0 +1,170 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	v, err := New()
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		config   string
+		expected []string
+	}{
+		{
+			name: "Valid config",
+			config: `server:
+  name: petstore
+  securitySchemes:
+    - id: ApiKeyAuth
+      type: apiKey
+tools:
+  - name: showPetById
+    description: Info for a specific pet
+    args:
+      - name: petId
+        type: string
+        position: path
+    requestTemplate:
+      url: /pets/{petId}
+      method: GET
+      security:
+        id: ApiKeyAuth
+`,
+		},
+		{
+			name: "Schema errors",
+			config: `server:
+  name: petstore
+tools:
+  - description: 42
+    args:
+      - name: limit
+        required: "yes"
+    requestTemplat:
+      url: /pets
+`,
+			expected: []string{
+				`4:5: error: missing required property "name"`,
+				`7:19: error: expected boolean, got string`,
+				`8:5: warning: unknown property "requestTemplat"`,
+			},
+		},
+		{
+			name: "Semantic errors",
+			config: `server:
+  name: petstore
+tools:
+  - name: showPetById
+    args:
+      - name: id
+        position: body-ish
+    requestTemplate:
+      url: /pets/{petId}
+      method: GET
+      security:
+        id: Missing
+  - name: showPetById
+    requestTemplate:
+      url: /pets
+      method: GET
+`,
+			expected: []string{
+				`7:19: error: invalid argument position "body-ish", expected one of path, query, header, cookie, body`,
+				`9:12: warning: path parameter {petId} has no argument with position path`,
+				`12:13: error: security scheme "Missing" is not defined in server.securitySchemes`,
+				`13:11: error: duplicate tool name "showPetById"`,
+			},
+		},
+		{
+			name: "Body paths",
+			config: `server:
+  name: users
+tools:
+  - name: createUser
+    args:
+      - name: user_address_city
+        position: body.user.address.city
+      - name: user_name
+        position: body..name
+    requestTemplate:
+      url: /users
+      method: POST
+`,
+			expected: []string{
+				`9:19: error: invalid argument position "body..name", expected one of path, query, header, cookie, body`,
+			},
+		},
+		{
+			name: "Credential references",
+			config: `server:
+  name: petstore
+  securitySchemes:
+    - id: ApiKeyAuth
+      type: apiKey
+      in: header
+      name: X-API-Key
+      credentialFrom:
+        env: PETSTORE_API_KEY
+    - id: BearerAuth
+      type: http
+      scheme: bearer
+      credentialFrom:
+        env: PETSTORE_TOKEN
+        file: /run/secrets/petstore
+tools: []
+`,
+			expected: []string{
+				`14:9: error: credentialFrom must set exactly one of env, file, secret`,
+			},
+		},
+		{
+			name: "Undefined schema refs",
+			config: `server:
+  name: petstore
+schemas:
+  Pet:
+    properties:
+      owner:
+        name: owner
+        type: object
+        ref: Owner
+tools:
+  - name: createPet
+    args:
+      - name: pet
+        type: object
+        ref: Pet
+      - name: tags
+        type: array
+        items:
+          name: ""
+          type: object
+          ref: Tag
+`,
+			expected: []string{
+				`9:14: error: schema "Owner" is not defined in schemas`,
+				`21:16: error: schema "Tag" is not defined in schemas`,
+			},
+		},
+		{
+			name:     "Syntax error",
+			config:   "server:\n  name: [unclosed\n",
+			expected: []string{"1:1: error: yaml: line 1: did not find expected ',' or ']'"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var actual []string
+			for _, d := range v.Validate([]byte(tc.config)) {
+				actual = append(actual, d.String())
+			}
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
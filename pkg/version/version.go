@@ -0,0 +1,29 @@
+// Package version holds build information injected at link time, e.g.
+//
+//	go build -ldflags "-X github.com/higress-group/openapi-to-mcpserver/pkg/version.Version=v1.2.0 \
+//	  -X github.com/higress-group/openapi-to-mcpserver/pkg/version.Commit=$(git rev-parse --short HEAD)"
+package version
+
+// Build information, overridden via -ldflags "-X ..."
+var (
+	// Name is the name of the generator
+	Name = "openapi-to-mcp"
+	// Version is the release version of the binary
+	Version = "dev"
+	// Commit is the VCS revision the binary was built from
+	Commit = ""
+	// BuildDate is the time the binary was built
+	BuildDate = ""
+)
+
+// String returns a human readable version string
+func String() string {
+	s := Name + " " + Version
+	if Commit != "" {
+		s += " (" + Commit + ")"
+	}
+	if BuildDate != "" {
+		s += " built " + BuildDate
+	}
+	return s
+}